@@ -0,0 +1,100 @@
+package gogsheet
+
+import (
+	"fmt"
+	"sync"
+)
+
+// appenderGrowRows is the slack added when a ColumnAppender grows the grid.
+const appenderGrowRows = 1000
+
+// ColumnAppender appends values down one column of a sheet, for producers
+// that each own a column of a shared sheet. It finds the first free cell
+// below the column's last value once and tracks it afterwards, so other
+// producers must not write to the same column.
+type ColumnAppender struct {
+	is            *Gsheet
+	mutex         sync.Mutex
+	spreadsheetId string
+	sheet         string
+	col           int
+	next          int // next free row, -1 until scanned
+	gridRows      int
+	sheetid       int64
+}
+
+// ColumnAppender returns an appender on the zero-based column of sheetName.
+func (is *Gsheet) ColumnAppender(sheetName string, column int, sprids ...string) *ColumnAppender {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return &ColumnAppender{is: is, spreadsheetId: spreadsheetId, sheet: sheetName, col: column, next: -1}
+}
+
+func (a *ColumnAppender) scan() error {
+	resp, err := a.is.getValues(a.spreadsheetId, ColumnsRange(a.sheet, a.col, a.col).String(), ReadOptions{MajorDimension: DimensionColumns})
+	if err != nil {
+		return err
+	}
+	next := 0
+	if len(resp.Values) != 0 {
+		next = len(resp.Values[0])
+	}
+	info, err := a.is.describeSpreadsheet(a.spreadsheetId)
+	if err != nil {
+		return err
+	}
+	for _, sh := range info.Sheets {
+		if sh.Title == a.sheet {
+			a.next, a.gridRows, a.sheetid = next, int(sh.RowCount), sh.SheetId
+			return nil
+		}
+	}
+	return fmt.Errorf("can not find sheet %s", a.sheet)
+}
+
+// Append writes values below the last value of the column, growing the grid
+// when needed. On error the position is scanned again on the next call.
+func (a *ColumnAppender) Append(values ...interface{}) (err error) {
+	if len(values) == 0 {
+		return nil
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	defer func() {
+		if err != nil {
+			a.next = -1
+		}
+	}()
+	if a.next < 0 {
+		if err = a.scan(); err != nil {
+			return err
+		}
+	}
+	if need := a.next + len(values); need > a.gridRows {
+		grow := need - a.gridRows + appenderGrowRows
+		if _, err = a.is.Batch(a.spreadsheetId).AppendDimension(a.sheetid, DimensionRows, grow).Do(); err != nil {
+			return err
+		}
+		a.gridRows += grow
+	}
+	rows := make([][]interface{}, len(values))
+	for i, v := range values {
+		rows[i] = []interface{}{v}
+	}
+	r := &Range{Sheet: a.sheet, StartCol: a.col, StartRow: a.next, EndCol: a.col, EndRow: a.next + len(values) - 1}
+	if err = a.is.UpdateRange(rows, r.String(), a.spreadsheetId); err != nil {
+		return err
+	}
+	a.next += len(values)
+	return nil
+}
+
+// Next returns the zero-based row the next value goes to, -1 before the first
+// Append or after an error.
+func (a *ColumnAppender) Next() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.next
+}
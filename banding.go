@@ -0,0 +1,112 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// BandingTheme holds the colors of alternating row banding. A nil header or
+// footer color leaves that row unbanded.
+type BandingTheme struct {
+	HeaderColor     *sheets.Color
+	FirstBandColor  *sheets.Color
+	SecondBandColor *sheets.Color
+	FooterColor     *sheets.Color
+}
+
+var (
+	// BlueBanding is white and light blue rows under a blue header.
+	BlueBanding = BandingTheme{
+		HeaderColor:     &sheets.Color{Red: 0.74, Green: 0.83, Blue: 0.93},
+		FirstBandColor:  &sheets.Color{Red: 1, Green: 1, Blue: 1},
+		SecondBandColor: &sheets.Color{Red: 0.93, Green: 0.95, Blue: 0.98},
+	}
+	// GreenBanding is white and light green rows under a green header.
+	GreenBanding = BandingTheme{
+		HeaderColor:     &sheets.Color{Red: 0.72, Green: 0.88, Blue: 0.8},
+		FirstBandColor:  &sheets.Color{Red: 1, Green: 1, Blue: 1},
+		SecondBandColor: &sheets.Color{Red: 0.91, Green: 0.96, Blue: 0.93},
+	}
+	// GreyBanding is white and light grey rows under a grey header.
+	GreyBanding = BandingTheme{
+		HeaderColor:     &sheets.Color{Red: 0.8, Green: 0.8, Blue: 0.8},
+		FirstBandColor:  &sheets.Color{Red: 1, Green: 1, Blue: 1},
+		SecondBandColor: &sheets.Color{Red: 0.95, Green: 0.95, Blue: 0.95},
+	}
+)
+
+func (t BandingTheme) properties() *sheets.BandingProperties {
+	return &sheets.BandingProperties{
+		HeaderColor:     t.HeaderColor,
+		FirstBandColor:  t.FirstBandColor,
+		SecondBandColor: t.SecondBandColor,
+		FooterColor:     t.FooterColor,
+	}
+}
+
+// BandingPreset bands the rows of a range with theme, see AddBanding.
+func BandingPreset(theme BandingTheme) Preset {
+	return func(gr *sheets.GridRange) []*sheets.Request {
+		return []*sheets.Request{{AddBanding: &sheets.AddBandingRequest{BandedRange: &sheets.BandedRange{
+			Range:         gr,
+			RowProperties: theme.properties(),
+		}}}}
+	}
+}
+
+// AddBanding colors the rows of rangeA1 alternately with theme, the first
+// row as header, and returns the id of the banded range. A range can only be
+// banded once; RemoveBanding clears it first.
+func (is *Gsheet) AddBanding(rangeA1 string, theme BandingTheme, sprids ...string) (int64, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := is.Batch(spreadsheetId).Preset(sheetid, r, BandingPreset(theme)).Do()
+	if err != nil || is.recorder != nil {
+		return 0, err
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].AddBanding == nil {
+		return 0, fmt.Errorf("can not find banded range after add")
+	}
+	return resp.Replies[0].AddBanding.BandedRange.BandedRangeId, nil
+}
+
+// RemoveBanding removes every banding overlapping rangeA1, keeping the cell
+// formats, and returns how many were removed.
+func (is *Gsheet) RemoveBanding(rangeA1 string, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := is.GetMetadataOf(spreadsheetId, "sheets(properties.sheetId,bandedRanges(bandedRangeId,range))")
+	if err != nil {
+		return 0, err
+	}
+	target := *r
+	target.Sheet = ""
+	b := is.Batch(spreadsheetId)
+	for _, sh := range resp.Sheets {
+		if sh.Properties.SheetId != sheetid {
+			continue
+		}
+		for _, br := range sh.BandedRanges {
+			if br.Range != nil && gridToRange("", br.Range).Overlaps(&target) {
+				b.Raw(&sheets.Request{DeleteBanding: &sheets.DeleteBandingRequest{BandedRangeId: br.BandedRangeId}})
+			}
+		}
+	}
+	if _, err = b.Do(); err != nil {
+		return 0, err
+	}
+	return b.Len(), nil
+}
@@ -0,0 +1,323 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Batch collects structural requests and sends them in a single
+// spreadsheets.batchUpdate call. High-level operations and raw
+// *sheets.Request items can be mixed freely and keep their order.
+type Batch struct {
+	is            *Gsheet
+	spreadsheetId string
+	requests      []*sheets.Request
+	checks        []func() error
+	include       bool
+	gridData      bool
+	ranges        []string
+}
+
+// BatchResult is the parsed reply of a batch.
+type BatchResult struct {
+	Replies []*sheets.Response // one per request, in order
+	// Spreadsheet and Info are only set when IncludeSpreadsheet was requested.
+	Spreadsheet *sheets.Spreadsheet
+	Info        *SpreadsheetInfo
+}
+
+// gridOf parses rangeA1 and resolves the id of its sheet. A name that is
+// not a sheet is looked up among the named ranges.
+func (is *Gsheet) gridOf(spreadsheetId, rangeA1 string) (int64, *Range, error) {
+	r, err := ParseRange(rangeA1)
+	if err != nil {
+		return 0, nil, err
+	}
+	sheetid, err := is.GetSheetIdFromNAme(r.Sheet, spreadsheetId)
+	if err != nil && bareName(rangeA1) {
+		if nr, named, nerr := is.namedRange(spreadsheetId, rangeA1); nerr == nil && nr != nil {
+			return nr.Range.SheetId, named, nil
+		}
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("sheet %s: %w", r.Sheet, err)
+	}
+	return sheetid, r, nil
+}
+
+func (is *Gsheet) Batch(sprids ...string) *Batch {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return &Batch{is: is, spreadsheetId: spreadsheetId}
+}
+
+// Raw appends requests the wrapper has no helper for. Requests writing to
+// cells are checked against the guards like the typed helpers; a
+// FindReplace on all sheets fails if any sheet or range is guarded.
+func (b *Batch) Raw(reqs ...*sheets.Request) *Batch {
+	for _, req := range reqs {
+		grids, all := requestGrids(req)
+		if len(grids) == 0 && !all {
+			continue
+		}
+		b.checks = append(b.checks, func() error {
+			if all && len(b.is.guardsOf(b.spreadsheetId)) != 0 {
+				return fmt.Errorf("%w: find and replace on all sheets", ErrPolicy)
+			}
+			for _, gr := range grids {
+				if err := b.is.checkGrid(b.spreadsheetId, gr.SheetId, gridToRange("", gr)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	b.requests = append(b.requests, reqs...)
+	return b
+}
+
+// requestGrids returns the cells req may change; all is set when it spans
+// every sheet.
+func requestGrids(req *sheets.Request) (grids []*sheets.GridRange, all bool) {
+	add := func(gr *sheets.GridRange) {
+		if gr != nil {
+			grids = append(grids, gr)
+		}
+	}
+	// from returns the range of rows x cols cells at c, open ended when 0
+	from := func(c *sheets.GridCoordinate, rows, cols int64) *sheets.GridRange {
+		gr := &sheets.GridRange{SheetId: c.SheetId, StartRowIndex: c.RowIndex, StartColumnIndex: c.ColumnIndex}
+		if rows > 0 {
+			gr.EndRowIndex = c.RowIndex + rows
+		}
+		if cols > 0 {
+			gr.EndColumnIndex = c.ColumnIndex + cols
+		}
+		return gr
+	}
+	dimension := func(dr *sheets.DimensionRange) *sheets.GridRange {
+		if dr == nil {
+			return nil
+		}
+		if dr.Dimension == DimensionColumns {
+			return &sheets.GridRange{SheetId: dr.SheetId, StartColumnIndex: dr.StartIndex, EndColumnIndex: dr.EndIndex}
+		}
+		return &sheets.GridRange{SheetId: dr.SheetId, StartRowIndex: dr.StartIndex, EndRowIndex: dr.EndIndex}
+	}
+	switch {
+	case req.RepeatCell != nil:
+		add(req.RepeatCell.Range)
+	case req.UpdateCells != nil:
+		if uc := req.UpdateCells; uc.Range != nil {
+			add(uc.Range)
+		} else if uc.Start != nil && len(uc.Rows) != 0 {
+			cols := 1
+			for _, row := range uc.Rows {
+				cols = max(cols, len(row.Values))
+			}
+			add(from(uc.Start, int64(len(uc.Rows)), int64(cols)))
+		}
+	case req.AutoFill != nil:
+		if af := req.AutoFill; af.Range != nil {
+			add(af.Range)
+		} else if sd := af.SourceAndDestination; sd != nil && sd.Source != nil {
+			dst := *sd.Source
+			start, end := &dst.StartRowIndex, &dst.EndRowIndex
+			if sd.Dimension == DimensionColumns {
+				start, end = &dst.StartColumnIndex, &dst.EndColumnIndex
+			}
+			if sd.FillLength >= 0 {
+				*start, *end = *end, *end+sd.FillLength
+			} else {
+				*start, *end = *start+sd.FillLength, *start
+			}
+			add(&dst)
+		}
+	case req.CopyPaste != nil:
+		add(req.CopyPaste.Destination)
+	case req.CutPaste != nil:
+		if cp := req.CutPaste; cp.Source != nil && cp.Destination != nil {
+			add(cp.Source)
+			var rows, cols int64
+			if cp.Source.EndRowIndex > 0 {
+				rows = cp.Source.EndRowIndex - cp.Source.StartRowIndex
+			}
+			if cp.Source.EndColumnIndex > 0 {
+				cols = cp.Source.EndColumnIndex - cp.Source.StartColumnIndex
+			}
+			add(from(cp.Destination, rows, cols))
+		}
+	case req.PasteData != nil:
+		if req.PasteData.Coordinate != nil {
+			add(from(req.PasteData.Coordinate, 0, 0))
+		}
+	case req.FindReplace != nil:
+		switch fr := req.FindReplace; {
+		case fr.AllSheets:
+			all = true
+		case fr.Range != nil:
+			add(fr.Range)
+		default:
+			add(&sheets.GridRange{SheetId: fr.SheetId})
+		}
+	case req.SortRange != nil:
+		add(req.SortRange.Range)
+	case req.RandomizeRange != nil:
+		add(req.RandomizeRange.Range)
+	case req.TrimWhitespace != nil:
+		add(req.TrimWhitespace.Range)
+	case req.DeleteDuplicates != nil:
+		add(req.DeleteDuplicates.Range)
+	case req.TextToColumns != nil:
+		if src := req.TextToColumns.Source; src != nil {
+			// the split spills into the columns on the right
+			spill := *src
+			spill.EndColumnIndex = 0
+			add(&spill)
+		}
+	case req.SetDataValidation != nil:
+		add(req.SetDataValidation.Range)
+	case req.UpdateBorders != nil:
+		add(req.UpdateBorders.Range)
+	case req.MergeCells != nil:
+		add(req.MergeCells.Range)
+	case req.UnmergeCells != nil:
+		add(req.UnmergeCells.Range)
+	case req.DeleteRange != nil:
+		add(req.DeleteRange.Range)
+	case req.InsertRange != nil:
+		add(req.InsertRange.Range)
+	case req.DeleteDimension != nil:
+		add(dimension(req.DeleteDimension.Range))
+	case req.MoveDimension != nil:
+		add(dimension(req.MoveDimension.Source))
+	case req.DeleteSheet != nil:
+		add(&sheets.GridRange{SheetId: req.DeleteSheet.SheetId})
+	}
+	return grids, all
+}
+
+func (b *Batch) AddSheet(title string) *Batch {
+	return b.Raw(&sheets.Request{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: title}}})
+}
+
+func (b *Batch) DeleteSheet(sheetid int64) *Batch {
+	return b.Raw(&sheets.Request{DeleteSheet: &sheets.DeleteSheetRequest{SheetId: sheetid}})
+}
+
+// DeleteRange queues the same request as Gsheet.DeleteRange.
+func (b *Batch) DeleteRange(sheetid int64, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex int64) *Batch {
+	gridrange := deleteRangeRequest(sheetid, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex)
+	return b.Raw(&sheets.Request{DeleteRange: gridrange})
+}
+
+// UpdateSheetProperties queues an update of the fields of props, a mask
+// such as "title" or "gridProperties.frozenRowCount"; props.SheetId selects
+// the sheet.
+func (b *Batch) UpdateSheetProperties(props *sheets.SheetProperties, fields string) *Batch {
+	return b.Raw(&sheets.Request{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{Properties: props, Fields: fields}})
+}
+
+// UpdateSpreadsheetProperties queues an update of the fields of props, a
+// mask such as "title" or "locale,timeZone".
+func (b *Batch) UpdateSpreadsheetProperties(props *sheets.SpreadsheetProperties, fields string) *Batch {
+	return b.Raw(&sheets.Request{UpdateSpreadsheetProperties: &sheets.UpdateSpreadsheetPropertiesRequest{Properties: props, Fields: fields}})
+}
+
+// RepeatCell queues setting the fields of cell, a mask such as "note" or
+// "userEnteredFormat.textFormat.bold", on every cell of r.
+func (b *Batch) RepeatCell(sheetid int64, r *Range, cell *sheets.CellData, fields string) *Batch {
+	return b.Raw(&sheets.Request{RepeatCell: &sheets.RepeatCellRequest{Range: rangeToGrid(sheetid, r), Cell: cell, Fields: fields}})
+}
+
+// FormatRange queues setting the fields of format, a mask relative to
+// userEnteredFormat such as "textFormat.bold,backgroundColor", on every cell
+// of r.
+func (b *Batch) FormatRange(sheetid int64, r *Range, format *sheets.CellFormat, fields string) *Batch {
+	return b.RepeatCell(sheetid, r, &sheets.CellData{UserEnteredFormat: format}, "userEnteredFormat("+fields+")")
+}
+
+// SetDataValidation queues rule on every cell of r; a nil rule removes
+// validation.
+func (b *Batch) SetDataValidation(sheetid int64, r *Range, rule *DataValidation) *Batch {
+	return b.Raw(&sheets.Request{SetDataValidation: &sheets.SetDataValidationRequest{Range: rangeToGrid(sheetid, r), Rule: rule.rule()}})
+}
+
+// AddNamedRange queues naming r of the sheet with id sheetid.
+func (b *Batch) AddNamedRange(name string, sheetid int64, r *Range) *Batch {
+	return b.Raw(&sheets.Request{AddNamedRange: &sheets.AddNamedRangeRequest{NamedRange: &sheets.NamedRange{Name: name, Range: rangeToGrid(sheetid, r)}}})
+}
+
+// DeleteNamedRange queues removing a named range; the cells are kept.
+func (b *Batch) DeleteNamedRange(namedRangeId string) *Batch {
+	return b.Raw(&sheets.Request{DeleteNamedRange: &sheets.DeleteNamedRangeRequest{NamedRangeId: namedRangeId}})
+}
+
+// AppendDimension queues adding length empty rows, or columns with
+// DimensionColumns, at the end of the sheet.
+func (b *Batch) AppendDimension(sheetid int64, dimension string, length int) *Batch {
+	return b.Raw(&sheets.Request{AppendDimension: &sheets.AppendDimensionRequest{SheetId: sheetid, Dimension: dimension, Length: int64(length)}})
+}
+
+// IncludeSpreadsheet asks for the updated spreadsheet in the reply, limited
+// to responseRanges when given, so changes can be verified without a Get.
+func (b *Batch) IncludeSpreadsheet(responseRanges ...string) *Batch {
+	b.include = true
+	b.ranges = append(b.ranges, responseRanges...)
+	return b
+}
+
+// IncludeGridData also returns cell data of the response ranges, for checking
+// formats and values; implies IncludeSpreadsheet.
+func (b *Batch) IncludeGridData() *Batch {
+	b.include, b.gridData = true, true
+	return b
+}
+
+// Requests returns the queued requests in order.
+func (b *Batch) Requests() []*sheets.Request {
+	return b.requests
+}
+
+// Len returns the number of queued requests.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Do sends all queued requests at once; replies are in request order.
+// An empty batch sends nothing.
+func (b *Batch) Do() (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	if len(b.requests) == 0 {
+		return nil, nil
+	}
+	for _, check := range b.checks {
+		if err := check(); err != nil {
+			return nil, err
+		}
+	}
+	return b.is.batchUpdate(b.spreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests:                     b.requests,
+		IncludeSpreadsheetInResponse: b.include,
+		ResponseRanges:               b.ranges,
+		ResponseIncludeGridData:      b.gridData,
+	}, false)
+}
+
+// Apply is Do returning the parsed reply.
+func (b *Batch) Apply() (*BatchResult, error) {
+	resp, err := b.Do()
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return &BatchResult{}, nil
+	}
+	ret := &BatchResult{Replies: resp.Replies, Spreadsheet: resp.UpdatedSpreadsheet}
+	if resp.UpdatedSpreadsheet != nil {
+		ret.Info = newSpreadsheetInfo(resp.UpdatedSpreadsheet)
+	}
+	return ret, nil
+}
@@ -0,0 +1,30 @@
+package gogsheet
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestRawChecksGuards(t *testing.T) {
+	is := &Gsheet{spreadsheetId: "id"}
+	is.GuardSheetId(3, ReadOnly)
+	blocked := []*sheets.Request{
+		{RepeatCell: &sheets.RepeatCellRequest{Range: &sheets.GridRange{SheetId: 3, EndRowIndex: 1}}},
+		{AutoFill: &sheets.AutoFillRequest{SourceAndDestination: &sheets.SourceAndDestination{
+			Source: &sheets.GridRange{SheetId: 3, EndRowIndex: 1}, Dimension: DimensionRows, FillLength: 5}}},
+		{CopyPaste: &sheets.CopyPasteRequest{Source: &sheets.GridRange{SheetId: 1}, Destination: &sheets.GridRange{SheetId: 3}}},
+		{FindReplace: &sheets.FindReplaceRequest{Find: "a", AllSheets: true}},
+	}
+	for i, req := range blocked {
+		if _, err := is.Batch().Raw(req).Apply(); !errors.Is(err, ErrPolicy) {
+			t.Errorf("request %d: got %v, want ErrPolicy", i, err)
+		}
+	}
+	grids, _ := requestGrids(&sheets.Request{AutoFill: &sheets.AutoFillRequest{SourceAndDestination: &sheets.SourceAndDestination{
+		Source: &sheets.GridRange{SheetId: 3, StartRowIndex: 10, EndRowIndex: 12}, Dimension: DimensionRows, FillLength: -4}}})
+	if len(grids) != 1 || grids[0].StartRowIndex != 6 || grids[0].EndRowIndex != 10 {
+		t.Errorf("fill destination %+v", grids)
+	}
+}
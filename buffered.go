@@ -0,0 +1,152 @@
+package gogsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BufferedWriterOptions configures a BufferedWriter. Zero values disable the
+// matching trigger.
+type BufferedWriterOptions struct {
+	MaxRows  int           // flush once this many rows are queued
+	Interval time.Duration // flush periodically
+	OnError  func(error)   // receives errors of periodic flushes
+	Write    WriteOptions
+}
+
+// BufferedWriter queues range updates and row appends and sends them
+// together: all updates in one values.batchUpdate and one append per target
+// range. Rows of a failed flush are dropped and the error is returned, or
+// passed to OnError for periodic flushes. Close flushes it too.
+type BufferedWriter struct {
+	is            *Gsheet
+	spreadsheetId string
+	opts          BufferedWriterOptions
+	mutex         sync.Mutex
+	flushing      sync.Mutex
+	rowsArray     [][][]interface{}
+	ranges        []string
+	appends       map[string][][]interface{}
+	appendOrder   []string
+	pending       int
+	closed        bool
+	poller        *poller
+	remove        func()
+}
+
+// NewBufferedWriter returns a writer on the spreadsheet; it stops and flushes
+// on Close of the writer or of the client.
+func (is *Gsheet) NewBufferedWriter(opts BufferedWriterOptions, sprids ...string) *BufferedWriter {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	w := &BufferedWriter{is: is, spreadsheetId: spreadsheetId, opts: opts, appends: map[string][][]interface{}{}}
+	if opts.Interval > 0 {
+		w.poller, _ = is.startPoller(opts.Interval, func() {
+			if err := w.Flush(); err != nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+		})
+	}
+	w.remove = is.onClose(func(ctx context.Context) error {
+		return w.close()
+	})
+	return w
+}
+
+// Update queues rows to be written to rangeA1.
+func (w *BufferedWriter) Update(rows [][]interface{}, rangeA1 string) error {
+	return w.queue(func() {
+		w.rowsArray = append(w.rowsArray, rows)
+		w.ranges = append(w.ranges, rangeA1)
+	}, len(rows))
+}
+
+// SetCell queues one value for the zero-based (col, row) cell of sheetName.
+func (w *BufferedWriter) SetCell(sheetName string, col, row int, value interface{}) error {
+	return w.Update([][]interface{}{{value}}, CellRange(sheetName, col, row).String())
+}
+
+// Append queues rows to be appended below the table found in rangeData.
+// Rows appended to the same range land in queue order.
+func (w *BufferedWriter) Append(rangeData string, rows ...[]interface{}) error {
+	return w.queue(func() {
+		if _, ok := w.appends[rangeData]; !ok {
+			w.appendOrder = append(w.appendOrder, rangeData)
+		}
+		w.appends[rangeData] = append(w.appends[rangeData], rows...)
+	}, len(rows))
+}
+
+func (w *BufferedWriter) queue(add func(), rows int) error {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return ErrClosed
+	}
+	add()
+	w.pending += rows
+	full := w.opts.MaxRows > 0 && w.pending >= w.opts.MaxRows
+	w.mutex.Unlock()
+	if full {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Pending returns the number of queued rows.
+func (w *BufferedWriter) Pending() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.pending
+}
+
+// Flush sends everything queued so far.
+func (w *BufferedWriter) Flush() error {
+	w.flushing.Lock()
+	defer w.flushing.Unlock()
+	w.mutex.Lock()
+	rowsArray, ranges := w.rowsArray, w.ranges
+	appends, order := w.appends, w.appendOrder
+	w.rowsArray, w.ranges = nil, nil
+	w.appends, w.appendOrder = map[string][][]interface{}{}, nil
+	w.pending = 0
+	w.mutex.Unlock()
+	errs := []error{}
+	if len(ranges) != 0 {
+		if err := w.is.UpdateRangesWith(rowsArray, ranges, w.opts.Write, w.spreadsheetId); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, rangeData := range order {
+		if _, err := w.is.AppendRowsWith(appends[rangeData], rangeData, w.opts.Write, w.spreadsheetId); err != nil {
+			errs = append(errs, fmt.Errorf("append %s: %w", rangeData, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops periodic flushes and flushes what is left; later writes fail
+// with ErrClosed.
+func (w *BufferedWriter) Close() error {
+	w.remove()
+	return w.close()
+}
+
+func (w *BufferedWriter) close() error {
+	w.mutex.Lock()
+	if w.closed {
+		w.mutex.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mutex.Unlock()
+	if w.poller != nil {
+		w.poller.Stop()
+	}
+	return w.Flush()
+}
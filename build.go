@@ -0,0 +1,113 @@
+package gogsheet
+
+import (
+	"errors"
+	"maps"
+	"sync"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ErrBuildOnly is returned by reads on a client passed to BuildRequests.
+var ErrBuildOnly = errors.New("reads are not available while building requests")
+
+// Call is one API request a high-level method sends. Only the fields of the
+// given Method are set.
+type Call struct {
+	Method           string // values.update, values.batchUpdate, values.batchUpdateByDataFilter, values.append, values.batchClearByDataFilter, values.clear, values.batchClear, batchUpdate or sheets.copyTo
+	SpreadsheetId    string
+	Range            string
+	ValueInputOption string
+	InsertDataOption string
+	ValueRanges      []*sheets.ValueRange
+	FilteredRanges   []*sheets.DataFilterValueRange
+	DataFilters      []*sheets.DataFilter
+	Ranges           []string
+	Requests         []*sheets.Request
+	SheetId          int64  // sheets.copyTo source
+	Destination      string // sheets.copyTo target spreadsheet
+}
+
+type recorder struct {
+	mutex sync.Mutex
+	calls []Call
+}
+
+func (rec *recorder) add(c Call) {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	rec.calls = append(rec.calls, c)
+}
+
+// BuildRequests runs fn against a copy of the client that records the API
+// requests its mutations would send instead of sending them. Reads fail with
+// ErrBuildOnly, so the result never depends on the network. The recorded
+// sheets.Request items can be asserted on in tests or merged into own batches.
+func (is *Gsheet) BuildRequests(fn func(g *Gsheet) error) ([]Call, error) {
+	b := is.clone()
+	b.recorder = new(recorder)
+	err := fn(b)
+	return b.recorder.calls, err
+}
+
+// clone returns a client sharing the services and settings of is, with its own locks.
+func (is *Gsheet) clone() *Gsheet {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	return &Gsheet{
+		locks:                      map[string]*sync.RWMutex{},
+		TokenOauth2_Or_Credentials: is.TokenOauth2_Or_Credentials,
+		oauthPath:                  is.oauthPath,
+		spreadsheetId:              is.spreadsheetId,
+		trim:                       is.trim,
+		readOptions:                is.readOptions,
+		writeOptions:               is.writeOptions,
+		retryPolicy:                is.retryPolicy,
+		role:                       is.role,
+		safeBatch:                  is.safeBatch,
+		tuner:                      is.tuner,
+		life:                       is.life,
+		emptyResults:               is.emptyResults,
+		cache:                      is.cache,
+		sanitize:                   is.sanitize,
+		guards:                     append([]guardRule(nil), is.guards...),
+		masks:                      append([]maskRule(nil), is.masks...),
+		locales:                    maps.Clone(is.locales),
+		recorder:                   is.recorder,
+		Service:                    is.Service,
+		clientOption:               is.clientOption,
+		drive:                      is.drive,
+		script:                     is.script,
+		ctx:                        is.ctx,
+	}
+}
+
+// readable fails with ErrBuildOnly while building requests.
+func (is *Gsheet) readable() error {
+	if is.recorder != nil {
+		return ErrBuildOnly
+	}
+	return nil
+}
+
+// batchUpdate sends structural requests in one spreadsheets.batchUpdate call,
+// or records them while building requests.
+func (is *Gsheet) batchUpdate(spreadsheetId string, rq *sheets.BatchUpdateSpreadsheetRequest, idempotent bool) (resp *sheets.BatchUpdateSpreadsheetResponse, err error) {
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "batchUpdate", SpreadsheetId: spreadsheetId, Requests: rq.Requests})
+		return &sheets.BatchUpdateSpreadsheetResponse{SpreadsheetId: spreadsheetId}, nil
+	}
+	defer is.invalidate(spreadsheetId)
+	call := func() (err error) {
+		resp, err = is.Spreadsheets.BatchUpdate(spreadsheetId, rq).Do()
+		return err
+	}
+	if idempotent {
+		// the lock is released during the backoff between attempts
+		err = is.retryLocked(is.lock, spreadsheetId, call)
+		return resp, err
+	}
+	defer is.lock(spreadsheetId)()
+	err = is.retry(false, call)
+	return resp, err
+}
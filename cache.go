@@ -0,0 +1,130 @@
+package gogsheet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// valueCache keeps GetValueRange results for a TTL. gen is bumped by every
+// invalidation so a read racing with a write never stores stale rows.
+type valueCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	gen     uint64
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	spreadsheetId string
+	rng           *Range // nil when the range could not be parsed
+	rows          [][]string
+	expires       time.Time
+}
+
+// EnableCache makes GetValueRange, GetValueRangeWith and GetValueCell serve
+// repeated reads of the same range from memory for ttl. Writes made through
+// this client drop the cached ranges they overlap; structural changes drop
+// the whole spreadsheet. Edits made elsewhere show up once entries expire or
+// after Invalidate. A ttl <= 0 disables the cache.
+func (is *Gsheet) EnableCache(ttl time.Duration) {
+	if ttl <= 0 {
+		is.cache = nil
+		return
+	}
+	is.cache = &valueCache{ttl: ttl, entries: map[string]*cacheEntry{}}
+}
+
+// Invalidate drops the cached ranges overlapping rangeA1; a name alone, which
+// may be a named range, drops the whole spreadsheet.
+func (is *Gsheet) Invalidate(rangeA1 string, sprids ...string) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	is.invalidate(spreadsheetId, rangeA1)
+}
+
+// InvalidateAll empties the cache.
+func (is *Gsheet) InvalidateAll() {
+	if c := is.cache; c != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.gen++
+		c.entries = map[string]*cacheEntry{}
+	}
+}
+
+// invalidate drops the entries of spreadsheetId overlapping rangesA1, every
+// entry of spreadsheetId when no range is given.
+func (is *Gsheet) invalidate(spreadsheetId string, rangesA1 ...string) {
+	c := is.cache
+	if c == nil {
+		return
+	}
+	targets := []*Range{}
+	for _, a1 := range rangesA1 {
+		r, err := ParseRange(a1)
+		if err != nil || bareName(a1) {
+			targets = nil // unknown target or named range, drop the whole spreadsheet
+			rangesA1 = nil
+			break
+		}
+		targets = append(targets, r)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.gen++
+	for key, e := range c.entries {
+		if e.spreadsheetId != spreadsheetId {
+			continue
+		}
+		drop := len(rangesA1) == 0 || e.rng == nil
+		for _, t := range targets {
+			drop = drop || e.rng.Overlaps(t)
+		}
+		if drop {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func cacheKey(spreadsheetId, readRange string, opts ReadOptions, trim TrimPolicy) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%s\x00%d", spreadsheetId, readRange,
+		opts.ValueRenderOption, opts.DateTimeRenderOption, opts.MajorDimension, trim)
+}
+
+func copyRows(rows [][]string) [][]string {
+	ret := make([][]string, len(rows))
+	for i, row := range rows {
+		ret[i] = append([]string(nil), row...)
+	}
+	return ret
+}
+
+// lookup returns a copy of the cached rows of key and the generation to pass
+// to store on a miss.
+func (c *valueCache) lookup(key string) ([][]string, bool, uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false, c.gen
+	}
+	return copyRows(e.rows), true, c.gen
+}
+
+// store caches rows unless an invalidation happened since lookup returned gen.
+func (c *valueCache) store(key, spreadsheetId, readRange string, rows [][]string, gen uint64) {
+	r, err := ParseRange(readRange)
+	if err != nil || bareName(readRange) {
+		r = nil // a bare name may be a named range anywhere
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if gen != c.gen {
+		return
+	}
+	c.entries[key] = &cacheEntry{spreadsheetId: spreadsheetId, rng: r, rows: copyRows(rows), expires: time.Now().Add(c.ttl)}
+}
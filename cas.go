@@ -0,0 +1,58 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ErrConflict is returned by CompareAndSwapCell when the cell no longer holds
+// the expected value.
+var ErrConflict = errors.New("cell changed")
+
+// CompareAndSwapCell writes newValue to cellAddress of sheetName, e.g. "B2",
+// only if the cell still shows oldValue, and fails with ErrConflict
+// otherwise. The read and the write hold the exclusive spreadsheet lock, even
+// with SetSafeBatch, so swaps made through this client never interleave;
+// other writers can still slip in between the two calls, so workers sharing
+// a status cell should re-check what they claimed.
+func (is *Gsheet) CompareAndSwapCell(sheetName, cellAddress, oldValue string, newValue interface{}, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	r, err := sheetCell(sheetName, cellAddress)
+	if err != nil {
+		return err
+	}
+	cell := r.String()
+	if err = is.checkRanges(spreadsheetId, cell); err != nil {
+		return err
+	}
+	if err = is.readable(); err != nil {
+		return err
+	}
+	valueRange := &sheets.ValueRange{Values: is.prepareRows([][]interface{}{{newValue}}, is.writeOptions)}
+	defer is.invalidate(spreadsheetId, cell)
+	defer is.lock(spreadsheetId)()
+	var resp *sheets.ValueRange
+	err = is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Values.Get(spreadsheetId, cell).Do()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	current := ""
+	if len(resp.Values) != 0 && len(resp.Values[0]) != 0 {
+		current = fmt.Sprint(resp.Values[0][0])
+	}
+	if current != oldValue {
+		return fmt.Errorf("%w: %s is %q, not %q", ErrConflict, cell, current, oldValue)
+	}
+	return is.retry(true, func() error {
+		_, err := is.Spreadsheets.Values.Update(spreadsheetId, cell, valueRange).ValueInputOption(is.writeOptions.valueInputOption()).Do()
+		return err
+	})
+}
@@ -0,0 +1,289 @@
+package gogsheet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ChangeOp is the kind of a RowChange.
+type ChangeOp string
+
+const (
+	RowInserted ChangeOp = "insert"
+	RowUpdated  ChangeOp = "update"
+	RowDeleted  ChangeOp = "delete"
+)
+
+// RowChange is one entry of a change feed. Row is nil for deletions.
+type RowChange struct {
+	Seq int64
+	Op  ChangeOp
+	Key string
+	Row []string
+}
+
+// ChangeFeed turns edits of a range into a stream of row changes. It keeps a
+// hidden shadow sheet with, per row key, the hash of the row, the sequence
+// number of its last change and that change's kind.
+//
+// The feed is compacted: a row changed several times since a token is
+// reported once, with its latest state. Deleted rows stay in the shadow sheet
+// as tombstones so consumers behind the deletion still see it.
+type ChangeFeed struct {
+	is            *Gsheet
+	mutex         sync.Mutex
+	spreadsheetId string
+	readRange     string
+	keyColumn     int
+	shadowSheet   string
+	shadowRows    int // rows of the shadow sheet when last read
+}
+
+type shadowRow struct {
+	hash string
+	seq  int64
+	op   ChangeOp
+}
+
+// NewChangeFeed creates a feed over readRange, whose first row is a header.
+// keyColumn is the zero-based column holding a stable row key within the
+// range; with a negative keyColumn each row is tagged with a generated id in
+// developer metadata, which follows the row when rows are inserted, deleted
+// or sorted. The shadow is kept in shadowSheet, created hidden on first use.
+func (is *Gsheet) NewChangeFeed(readRange string, keyColumn int, shadowSheet string, sprids ...string) *ChangeFeed {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return &ChangeFeed{is: is, spreadsheetId: spreadsheetId, readRange: readRange, keyColumn: keyColumn, shadowSheet: shadowSheet}
+}
+
+func rowHash(row []string) string {
+	end := len(row)
+	for end > 0 && isBlank(row[end-1]) {
+		end--
+	}
+	sum := sha256.Sum256([]byte(strings.Join(row[:end], "\x1f")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (f *ChangeFeed) loadShadow() (map[string]*shadowRow, error) {
+	titles, err := f.is.ListSheets(f.spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	f.shadowRows = 0
+	if _, ok := titles[f.shadowSheet]; !ok {
+		rq := &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: f.shadowSheet, Hidden: true}}}},
+		}
+		if _, err = f.is.batchUpdate(f.spreadsheetId, rq, false); err != nil {
+			return nil, err
+		}
+		return map[string]*shadowRow{}, nil
+	}
+	resp, err := f.is.getValues(f.spreadsheetId, OpenRange(f.shadowSheet, 0, 0, 3).String(), ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+	f.shadowRows = len(resp.Values)
+	shadow := map[string]*shadowRow{}
+	for _, row := range stringRows(resp.Values) {
+		if len(row) < 4 {
+			continue
+		}
+		seq, err := strconv.ParseInt(row[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("shadow sheet %s: bad sequence %q", f.shadowSheet, row[2])
+		}
+		shadow[row[0]] = &shadowRow{hash: row[1], seq: seq, op: ChangeOp(row[3])}
+	}
+	return shadow, nil
+}
+
+func (f *ChangeFeed) saveShadow(shadow map[string]*shadowRow) error {
+	keys := make([]string, 0, len(shadow))
+	for k := range shadow {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([][]interface{}, 0, len(keys))
+	for _, k := range keys {
+		s := shadow[k]
+		// the apostrophe keeps keys and hashes as text under USER_ENTERED
+		rows = append(rows, []interface{}{"'" + k, "'" + s.hash, s.seq, string(s.op)})
+	}
+	// pad over the previous shadow instead of clearing it first, so a failed
+	// write never loses the sequence numbers and tombstones
+	for len(rows) < f.shadowRows {
+		rows = append(rows, []interface{}{"", "", "", ""})
+	}
+	if err := f.is.UpdateRangeWith(rows, CellRange(f.shadowSheet, 0, 0).String(), WriteOptions{ValueInputOption: UserEntered}, f.spreadsheetId); err != nil {
+		return err
+	}
+	f.shadowRows = len(rows)
+	return nil
+}
+
+// rowIdKey tags the rows of a feed without key column with a generated id.
+const rowIdKey = "gogsheet.rowid"
+
+// rowIds returns the ids of the data rows of the feed, rows[i] being the
+// row below the header, and tags the non-blank rows that have none yet.
+// Blank rows have no id, so clearing a row reports it as deleted.
+func (f *ChangeFeed) rowIds(rows [][]string) ([]string, error) {
+	sheetid, r, err := f.is.gridOf(f.spreadsheetId, f.readRange)
+	if err != nil {
+		return nil, err
+	}
+	rq := &sheets.SearchDeveloperMetadataRequest{DataFilters: []*sheets.DataFilter{{
+		DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{MetadataKey: rowIdKey, LocationType: "ROW"},
+	}}}
+	var resp *sheets.SearchDeveloperMetadataResponse
+	err = func() error {
+		defer f.is.rlock(f.spreadsheetId)()
+		return f.is.retry(true, func() (err error) {
+			resp, err = f.is.Spreadsheets.DeveloperMetadata.Search(f.spreadsheetId, rq).Do()
+			return err
+		})
+	}()
+	if err != nil {
+		return nil, err
+	}
+	first := max(r.StartRow, 0) + 1
+	ids := make([]string, len(rows))
+	for _, m := range resp.MatchedDeveloperMetadata {
+		md := m.DeveloperMetadata
+		if md == nil || md.Location == nil || md.Location.DimensionRange == nil || md.Location.DimensionRange.SheetId != sheetid {
+			continue
+		}
+		if i := int(md.Location.DimensionRange.StartIndex) - first; i >= 0 && i < len(ids) {
+			ids[i] = md.MetadataValue
+		}
+	}
+	b := f.is.Batch(f.spreadsheetId)
+	tagged := false
+	for i, row := range rows {
+		if isBlank(strings.Join(row, "")) {
+			ids[i] = ""
+			continue
+		}
+		if len(ids[i]) != 0 {
+			continue
+		}
+		ids[i] = newDedupeToken()
+		b.Raw(&sheets.Request{CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{DeveloperMetadata: &sheets.DeveloperMetadata{
+			MetadataKey:   rowIdKey,
+			MetadataValue: ids[i],
+			Visibility:    "DOCUMENT",
+			Location: &sheets.DeveloperMetadataLocation{DimensionRange: &sheets.DimensionRange{
+				SheetId: sheetid, Dimension: "ROWS", StartIndex: int64(first + i), EndIndex: int64(first + i + 1)}},
+		}}})
+		tagged = true
+	}
+	if tagged {
+		if _, err = b.Do(); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// Changes returns the rows changed after sinceToken, ordered by sequence
+// number, and the token to resume from. An empty token returns every row.
+// Each call compares the range with the shadow sheet and records what changed.
+func (f *ChangeFeed) Changes(sinceToken string) ([]RowChange, string, error) {
+	var since int64
+	if len(sinceToken) != 0 {
+		var err error
+		if since, err = strconv.ParseInt(sinceToken, 10, 64); err != nil {
+			return nil, "", fmt.Errorf("invalid change token %q", sinceToken)
+		}
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	resp, err := f.is.getValues(f.spreadsheetId, f.readRange, f.is.readOptions)
+	if err != nil {
+		return nil, "", err
+	}
+	rows := stringRows(resp.Values)
+	if len(rows) != 0 {
+		rows = rows[1:]
+	}
+	shadow, err := f.loadShadow()
+	if err != nil {
+		return nil, "", err
+	}
+	var last int64
+	for _, s := range shadow {
+		last = max(last, s.seq)
+	}
+	var ids []string
+	if f.keyColumn < 0 {
+		if ids, err = f.rowIds(rows); err != nil {
+			return nil, "", err
+		}
+	}
+	current := map[string][]string{}
+	dirty := false
+	for i, row := range rows {
+		var key string
+		if f.keyColumn >= 0 {
+			if f.keyColumn >= len(row) || isBlank(row[f.keyColumn]) {
+				continue
+			}
+			key = strings.TrimSpace(row[f.keyColumn])
+		} else if key = ids[i]; len(key) == 0 {
+			continue
+		}
+		if _, dup := current[key]; dup {
+			return nil, "", fmt.Errorf("duplicate row key %q in %s", key, f.readRange)
+		}
+		current[key] = row
+		hash := rowHash(row)
+		s, ok := shadow[key]
+		switch {
+		case !ok || s.op == RowDeleted:
+			last++
+			shadow[key] = &shadowRow{hash: hash, seq: last, op: RowInserted}
+		case s.hash != hash:
+			last++
+			s.hash, s.seq, s.op = hash, last, RowUpdated
+		default:
+			continue
+		}
+		dirty = true
+	}
+	deleted := []string{}
+	for key, s := range shadow {
+		if _, ok := current[key]; !ok && s.op != RowDeleted {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(deleted)
+	for _, key := range deleted {
+		last++
+		shadow[key] = &shadowRow{seq: last, op: RowDeleted}
+		dirty = true
+	}
+	if dirty {
+		if err = f.saveShadow(shadow); err != nil {
+			return nil, "", err
+		}
+	}
+	changes := []RowChange{}
+	for key, s := range shadow {
+		if s.seq > since {
+			changes = append(changes, RowChange{Seq: s.seq, Op: s.op, Key: key, Row: current[key]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Seq < changes[j].Seq })
+	return changes, strconv.FormatInt(max(last, since), 10), nil
+}
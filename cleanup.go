@@ -0,0 +1,105 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// TrimWhitespace strips leading and trailing whitespace from every cell of
+// rangeA1 and collapses inner runs of whitespace, server side. It returns the
+// number of cells changed.
+func (is *Gsheet) TrimWhitespace(rangeA1 string, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, r); err != nil {
+		return 0, err
+	}
+	resp, err := is.Batch(spreadsheetId).Raw(&sheets.Request{TrimWhitespace: &sheets.TrimWhitespaceRequest{
+		Range: rangeToGrid(sheetid, r),
+	}}).Do()
+	if err != nil || resp == nil || len(resp.Replies) == 0 || resp.Replies[0].TrimWhitespace == nil {
+		return 0, err
+	}
+	return int(resp.Replies[0].TrimWhitespace.CellsChangedCount), nil
+}
+
+// RemoveDuplicates deletes the rows of rangeA1 that repeat an earlier row,
+// keeping the first occurrence, and returns how many were removed. Rows are
+// compared on comparisonColumns, zero-based offsets within the range, or on
+// all columns when empty. Include a header row in the range only if it cannot
+// be mistaken for data.
+func (is *Gsheet) RemoveDuplicates(rangeA1 string, comparisonColumns []int, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, r); err != nil {
+		return 0, err
+	}
+	startCol := max(r.StartCol, 0)
+	columns := []*sheets.DimensionRange{}
+	for _, c := range comparisonColumns {
+		if c < 0 || (r.EndCol >= 0 && startCol+c > r.EndCol) {
+			return 0, fmt.Errorf("comparison column %d outside %s", c, rangeA1)
+		}
+		columns = append(columns, &sheets.DimensionRange{SheetId: sheetid, Dimension: DimensionColumns, StartIndex: int64(startCol + c), EndIndex: int64(startCol + c + 1)})
+	}
+	resp, err := is.Batch(spreadsheetId).Raw(&sheets.Request{DeleteDuplicates: &sheets.DeleteDuplicatesRequest{
+		Range:             rangeToGrid(sheetid, r),
+		ComparisonColumns: columns,
+	}}).Do()
+	if err != nil || resp == nil || len(resp.Replies) == 0 || resp.Replies[0].DeleteDuplicates == nil {
+		return 0, err
+	}
+	return int(resp.Replies[0].DeleteDuplicates.DuplicatesRemovedCount), nil
+}
+
+// delimiterTypes maps delimiters the API names to their type.
+var delimiterTypes = map[string]string{
+	",": "COMMA",
+	";": "SEMICOLON",
+	".": "PERIOD",
+	" ": "SPACE",
+	"":  "AUTODETECT",
+}
+
+// SplitTextToColumns splits every cell of sourceColumnA1, a range within one
+// column such as "Import!C2:C", on delimiter and spreads the parts over the
+// columns to its right, overwriting them. An empty delimiter lets the
+// spreadsheet detect it.
+func (is *Gsheet) SplitTextToColumns(sourceColumnA1, delimiter string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, sourceColumnA1)
+	if err != nil {
+		return err
+	}
+	if r.StartCol < 0 || r.EndCol != r.StartCol {
+		return fmt.Errorf("%s is not a single column", sourceColumnA1)
+	}
+	// the parts may overwrite every column to the right of the source
+	written := *r
+	written.EndCol = -1
+	if err = is.checkGrid(spreadsheetId, sheetid, &written); err != nil {
+		return err
+	}
+	req := &sheets.TextToColumnsRequest{Source: rangeToGrid(sheetid, r), DelimiterType: "CUSTOM", Delimiter: delimiter}
+	if t, ok := delimiterTypes[delimiter]; ok {
+		req.DelimiterType, req.Delimiter = t, ""
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{TextToColumns: req}).Do()
+	return err
+}
@@ -0,0 +1,100 @@
+package gogsheet
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrClosed is returned by API calls made after Close.
+var ErrClosed = errors.New("gogsheet: client closed")
+
+// lifecycle tracks in-flight API calls and the background subsystems to stop
+// on Close. It is shared by a client and its clones.
+type lifecycle struct {
+	mutex    sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	closers  map[int]func(ctx context.Context) error
+	next     int
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{closers: map[int]func(ctx context.Context) error{}}
+}
+
+// begin marks the start of an API call; the returned func marks its end.
+func (is *Gsheet) begin() (func(), error) {
+	l := is.life
+	if l == nil {
+		return func() {}, nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.closed {
+		return nil, ErrClosed
+	}
+	l.inflight.Add(1)
+	return l.inflight.Done, nil
+}
+
+// onClose registers fn to run on Close, before in-flight calls are awaited so
+// fn can still flush. The returned func unregisters it.
+func (is *Gsheet) onClose(fn func(ctx context.Context) error) (remove func()) {
+	l := is.life
+	if l == nil {
+		return func() {}
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	id := l.next
+	l.next++
+	l.closers[id] = fn
+	return func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		delete(l.closers, id)
+	}
+}
+
+// Close stops watchers, flushes buffered writers in registration order, then
+// rejects new API calls with ErrClosed and waits for in-flight ones, or until
+// ctx is done. Errors of every step are joined.
+func (is *Gsheet) Close(ctx context.Context) error {
+	l := is.life
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	ids := make([]int, 0, len(l.closers))
+	for id := range l.closers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	closers := make([]func(ctx context.Context) error, 0, len(ids))
+	for _, id := range ids {
+		closers = append(closers, l.closers[id])
+	}
+	clear(l.closers)
+	l.mutex.Unlock()
+
+	errs := []error{}
+	for _, fn := range closers {
+		errs = append(errs, fn(ctx))
+	}
+	l.mutex.Lock()
+	l.closed = true
+	l.mutex.Unlock()
+	done := make(chan struct{})
+	go func() {
+		l.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, ctx.Err())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+// Command gogsheet-gateway serves gogsheet operations as JSON-RPC 2.0 over
+// HTTP, so services in other languages can share one set of Google
+// credentials. Callers authenticate with "Authorization: Bearer <key>"; the
+// keys file maps every key to the methods and spreadsheets it may use ("*"
+// for all), the default spreadsheet being given as "":
+//
+//	{
+//	  "reporting-key": {"methods": ["GetValueRange", "ListSheets"], "spreadsheets": ["1AbC..."]},
+//	  "admin-key": {"methods": ["*"], "spreadsheets": ["*"]}
+//	}
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sonnt85/gogsheet"
+)
+
+// keyGrant is what one API key may do.
+type keyGrant struct {
+	Methods      []string `json:"methods"`
+	Spreadsheets []string `json:"spreadsheets"`
+}
+
+func allowed(list []string, v string) bool {
+	return slices.Contains(list, "*") || slices.Contains(list, v)
+}
+
+func main() {
+	listen := flag.String("listen", ":8080", "listen address")
+	token := flag.String("token", "", "oauth2 token file, empty to use a service account")
+	credentials := flag.String("credentials", "", "service account or oauth2 client credentials file")
+	spreadsheet := flag.String("spreadsheet", "", "default spreadsheet id")
+	keysFile := flag.String("keys", "", "JSON file mapping API keys to allowed methods and spreadsheets")
+	rate := flag.Float64("rate", 10, "calls per second, 0 for unlimited")
+	burst := flag.Int("burst", 20, "rate limit burst")
+	flag.Parse()
+	if len(*credentials) == 0 || len(*keysFile) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	b, err := os.ReadFile(*keysFile)
+	if err != nil {
+		log.Fatalf("Unable to read keys: %v", err)
+	}
+	keys := map[string]keyGrant{}
+	if err = json.Unmarshal(b, &keys); err != nil {
+		log.Fatalf("Unable to parse keys: %v", err)
+	}
+	g, err := gogsheet.New(*token, *credentials, *spreadsheet)
+	if err != nil {
+		log.Fatalf("Unable to create sheets client: %v", err)
+	}
+
+	handler := g.RPCHandler(gogsheet.RPCOptions{
+		Authorize: func(r *http.Request, method string, params *gogsheet.RPCParams) error {
+			key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			grant, ok := keys[key]
+			if !ok || len(key) == 0 {
+				return errors.New("unknown api key")
+			}
+			if !allowed(grant.Methods, method) {
+				return fmt.Errorf("method %s not allowed for this key", method)
+			}
+			spreadsheetId := params.SpreadsheetId
+			if spreadsheetId == *spreadsheet {
+				spreadsheetId = ""
+			}
+			if !allowed(grant.Spreadsheets, spreadsheetId) && !allowed(grant.Spreadsheets, params.SpreadsheetId) {
+				return fmt.Errorf("spreadsheet %s not allowed for this key", params.SpreadsheetId)
+			}
+			return nil
+		},
+		RateLimit: *rate,
+		Burst:     *burst,
+	})
+	log.Printf("Serving %d methods on %s", len(gogsheet.RPCMethods()), *listen)
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      5 * time.Minute, // large reads and retried writes can be slow
+		IdleTimeout:       2 * time.Minute,
+	}
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,45 @@
+// Command gogsheet-gen generates a typed Go struct and ReadX/WriteX functions
+// from the header row of a sheet.
+//
+//	gogsheet-gen -credentials sa.json -spreadsheet <id> -sheet Orders -type Order -package models -o orders_gen.go
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/sonnt85/gogsheet"
+)
+
+func main() {
+	token := flag.String("token", "", "oauth2 token file, empty to use a service account")
+	credentials := flag.String("credentials", "", "service account or oauth2 client credentials file")
+	spreadsheet := flag.String("spreadsheet", "", "spreadsheet id")
+	sheet := flag.String("sheet", "", "sheet name")
+	typeName := flag.String("type", "", "struct name, derived from the sheet name by default")
+	pkg := flag.String("package", "models", "package of the generated file")
+	sample := flag.Int("sample", 200, "rows inspected to infer column types")
+	out := flag.String("o", "", "output file, stdout by default")
+	flag.Parse()
+	if len(*credentials) == 0 || len(*spreadsheet) == 0 || len(*sheet) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g, err := gogsheet.New(*token, *credentials, *spreadsheet)
+	if err != nil {
+		log.Fatalf("Unable to create sheets client: %v", err)
+	}
+	code, err := g.GenerateCode(*sheet, gogsheet.GenOptions{Package: *pkg, TypeName: *typeName, SampleRows: *sample})
+	if err != nil {
+		log.Fatalf("Unable to generate code: %v", err)
+	}
+	if len(*out) == 0 {
+		os.Stdout.Write(code)
+		return
+	}
+	if err = os.WriteFile(*out, code, 0644); err != nil {
+		log.Fatalf("Unable to write %s: %v", *out, err)
+	}
+}
@@ -0,0 +1,142 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Paste types for CopyRange.
+const (
+	PasteNormal                = "PASTE_NORMAL"
+	PasteValues                = "PASTE_VALUES"
+	PasteFormat                = "PASTE_FORMAT"
+	PasteFormula               = "PASTE_FORMULA"
+	PasteNoBorders             = "PASTE_NO_BORDERS"
+	PasteDataValidation        = "PASTE_DATA_VALIDATION"
+	PasteConditionalFormatting = "PASTE_CONDITIONAL_FORMATTING"
+)
+
+// anchoredRange returns the range of the size of src whose top-left cell is
+// the top-left cell of anchor.
+func anchoredRange(src, anchor *Range) *Range {
+	dst := &Range{Sheet: anchor.Sheet, StartCol: max(anchor.StartCol, 0), StartRow: max(anchor.StartRow, 0), EndCol: -1, EndRow: -1}
+	if src.EndCol >= 0 {
+		dst.EndCol = dst.StartCol + src.EndCol - max(src.StartCol, 0)
+	}
+	if src.EndRow >= 0 {
+		dst.EndRow = dst.StartRow + src.EndRow - max(src.StartRow, 0)
+	}
+	return dst
+}
+
+// CopyRange copies srcRangeA1 to the block of the same size starting at the
+// cell dstAnchorA1, e.g. "Week2!A10", in the same or another sheet.
+// pasteType selects what is copied: PasteNormal for everything, PasteValues,
+// PasteFormat, PasteFormula and so on; empty means PasteNormal.
+func (is *Gsheet) CopyRange(srcRangeA1, dstAnchorA1, pasteType string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(pasteType) == 0 {
+		pasteType = PasteNormal
+	}
+	srcId, src, err := is.gridOf(spreadsheetId, srcRangeA1)
+	if err != nil {
+		return err
+	}
+	dstId, anchor, err := is.gridOf(spreadsheetId, dstAnchorA1)
+	if err != nil {
+		return err
+	}
+	dst := anchoredRange(src, anchor)
+	if err = is.checkGrid(spreadsheetId, dstId, dst); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{CopyPaste: &sheets.CopyPasteRequest{
+		Source:      rangeToGrid(srcId, src),
+		Destination: rangeToGrid(dstId, dst),
+		PasteType:   pasteType,
+	}}).Do()
+	return err
+}
+
+// MoveRange moves srcRangeA1, values, formats and validation together, so
+// that its top-left cell lands on dstAnchorA1; the source is left empty.
+// It is one atomic request instead of a read, a write and a clear.
+func (is *Gsheet) MoveRange(srcRangeA1, dstAnchorA1 string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	srcId, src, err := is.gridOf(spreadsheetId, srcRangeA1)
+	if err != nil {
+		return err
+	}
+	dstId, anchor, err := is.gridOf(spreadsheetId, dstAnchorA1)
+	if err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, srcId, src); err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, dstId, anchoredRange(src, anchor)); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{CutPaste: &sheets.CutPasteRequest{
+		Source:      rangeToGrid(srcId, src),
+		Destination: &sheets.GridCoordinate{SheetId: dstId, RowIndex: int64(max(anchor.StartRow, 0)), ColumnIndex: int64(max(anchor.StartCol, 0))},
+		PasteType:   PasteNormal,
+	}}).Do()
+	return err
+}
+
+// AutoFill extends the data of sourceRangeA1 over fillRangeA1 like dragging
+// the fill handle: series continue and formulas are copied with shifted
+// references. fillRangeA1 lies above, below, left or right of the source,
+// spanning the same columns or rows, and may include the source.
+// useAlternateSeries picks the alternate series, e.g. copying "1" instead of
+// counting.
+func (is *Gsheet) AutoFill(sourceRangeA1, fillRangeA1 string, useAlternateSeries bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, src, err := is.gridOf(spreadsheetId, sourceRangeA1)
+	if err != nil {
+		return err
+	}
+	fill, err := ParseRange(fillRangeA1)
+	if err != nil {
+		return err
+	}
+	if len(fill.Sheet) == 0 {
+		fill.Sheet = src.Sheet
+	}
+	if fill.Sheet != src.Sheet || src.EndCol < 0 || src.EndRow < 0 || fill.EndCol < 0 || fill.EndRow < 0 {
+		return fmt.Errorf("autofill needs bounded ranges on one sheet, got %s and %s", sourceRangeA1, fillRangeA1)
+	}
+	var dimension string
+	var length int
+	switch {
+	case fill.StartCol == src.StartCol && fill.EndCol == src.EndCol && fill.EndRow > src.EndRow:
+		dimension, length = DimensionRows, fill.EndRow-src.EndRow
+	case fill.StartCol == src.StartCol && fill.EndCol == src.EndCol && fill.StartRow < src.StartRow:
+		dimension, length = DimensionRows, fill.StartRow-src.StartRow
+	case fill.StartRow == src.StartRow && fill.EndRow == src.EndRow && fill.EndCol > src.EndCol:
+		dimension, length = DimensionColumns, fill.EndCol-src.EndCol
+	case fill.StartRow == src.StartRow && fill.EndRow == src.EndRow && fill.StartCol < src.StartCol:
+		dimension, length = DimensionColumns, fill.StartCol-src.StartCol
+	default:
+		return fmt.Errorf("%s does not extend %s along rows or columns", fillRangeA1, sourceRangeA1)
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, fill); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{AutoFill: &sheets.AutoFillRequest{
+		SourceAndDestination: &sheets.SourceAndDestination{Source: rangeToGrid(sheetid, src), Dimension: dimension, FillLength: int64(length)},
+		UseAlternateSeries:   useAlternateSeries,
+	}}).Do()
+	return err
+}
@@ -0,0 +1,120 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// MetadataFilter selects the ranges tagged with developer metadata key=value,
+// which follow their rows and columns when they move.
+func MetadataFilter(key, value string) *sheets.DataFilter {
+	return &sheets.DataFilter{DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{MetadataKey: key, MetadataValue: value}}
+}
+
+// GridFilter selects r on the sheet with id sheetid; r.Sheet is ignored.
+func GridFilter(sheetid int64, r *Range) *sheets.DataFilter {
+	return &sheets.DataFilter{GridRange: rangeToGrid(sheetid, r)}
+}
+
+// A1Filter selects an A1 range.
+func A1Filter(rangeA1 string) *sheets.DataFilter {
+	return &sheets.DataFilter{A1Range: rangeA1}
+}
+
+// MatchedValues is a range selected by GetValuesByDataFilter.
+type MatchedValues struct {
+	Range   string // A1 range the filters resolved to
+	Filters []*sheets.DataFilter
+	Rows    [][]string
+}
+
+// checkFilters applies the guards to data filters. Developer metadata can not
+// be resolved to cells up front, so it is refused while guards deny the role.
+func (is *Gsheet) checkFilters(spreadsheetId string, filters []*sheets.DataFilter) error {
+	for _, f := range filters {
+		switch {
+		case len(f.A1Range) != 0:
+			if err := is.checkRanges(spreadsheetId, f.A1Range); err != nil {
+				return err
+			}
+		case f.GridRange != nil:
+			if err := is.checkGrid(spreadsheetId, f.GridRange.SheetId, gridToRange("", f.GridRange)); err != nil {
+				return err
+			}
+		default:
+			if len(is.guardsOf(spreadsheetId)) != 0 {
+				return fmt.Errorf("%w: metadata filters can not be checked against guarded ranges", ErrPolicy)
+			}
+		}
+	}
+	return nil
+}
+
+// GetValuesByDataFilter reads every range matched by filters in one
+// values.batchGetByDataFilter call, rendered per the read options.
+func (is *Gsheet) GetValuesByDataFilter(filters []*sheets.DataFilter, sprids ...string) ([]MatchedValues, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	opts := is.readOptions
+	rq := &sheets.BatchGetValuesByDataFilterRequest{
+		DataFilters:          filters,
+		ValueRenderOption:    opts.ValueRenderOption,
+		DateTimeRenderOption: opts.DateTimeRenderOption,
+		MajorDimension:       opts.MajorDimension,
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.BatchGetValuesByDataFilterResponse
+	err := is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Values.BatchGetByDataFilter(spreadsheetId, rq).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := []MatchedValues{}
+	for _, m := range resp.ValueRanges {
+		mv := MatchedValues{Filters: m.DataFilters}
+		if m.ValueRange != nil {
+			mv.Range = m.ValueRange.Range
+			mv.Rows = TrimRows(stringRows(m.ValueRange.Values), is.trim)
+		}
+		ret = append(ret, mv)
+	}
+	return ret, nil
+}
+
+// UpdateByDataFilter writes rowsArray[i] to the range matched by filters[i],
+// in one values.batchUpdateByDataFilter call.
+func (is *Gsheet) UpdateByDataFilter(filters []*sheets.DataFilter, rowsArray [][][]interface{}, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(filters) != len(rowsArray) {
+		return fmt.Errorf("filters and rowsArray need same len")
+	}
+	if err := is.checkFilters(spreadsheetId, filters); err != nil {
+		return err
+	}
+	opts := is.writeOptions
+	rq := &sheets.BatchUpdateValuesByDataFilterRequest{ValueInputOption: opts.valueInputOption()}
+	for i, rows := range rowsArray {
+		rq.Data = append(rq.Data, &sheets.DataFilterValueRange{DataFilter: filters[i], Values: is.prepareRows(rows, opts), MajorDimension: opts.majorDimension()})
+	}
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.batchUpdateByDataFilter", SpreadsheetId: spreadsheetId,
+			ValueInputOption: rq.ValueInputOption, FilteredRanges: rq.Data})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId)
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.BatchUpdateByDataFilter(spreadsheetId, rq).Do()
+		return err
+	})
+}
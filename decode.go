@@ -0,0 +1,224 @@
+package gogsheet
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sheetsEpoch is day zero of spreadsheet serial dates.
+var sheetsEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// SerialToTime converts a spreadsheet serial date number to a UTC time.
+func SerialToTime(serial float64) time.Time {
+	return sheetsEpoch.Add(time.Duration(math.Round(serial*24*float64(time.Hour/time.Millisecond))) * time.Millisecond)
+}
+
+// TimeToSerial converts t to a spreadsheet serial date number, keeping its wall clock.
+func TimeToSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return float64(wall.Sub(sheetsEpoch)) / float64(24*time.Hour)
+}
+
+// TimeLayouts are tried in order when a text cell is decoded into a time.Time.
+var TimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "01/02/2006 15:04:05", "01/02/2006"}
+
+type structField struct {
+	index []int
+	name  string
+}
+
+// structFields lists the exported fields of t with their column names, taken
+// from the `gsheet:"Name"` tag or the field name. Fields tagged "-" are skipped.
+func structFields(t reflect.Type) ([]structField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+	fields := []structField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("gsheet"); ok {
+			if tag = strings.Split(tag, ",")[0]; tag == "-" {
+				continue
+			} else if len(tag) != 0 {
+				name = tag
+			}
+		}
+		fields = append(fields, structField{index: f.Index, name: name})
+	}
+	return fields, nil
+}
+
+// Get reads readRange into a slice of struct T. The first row is the header
+// and columns are matched to fields by `gsheet` tag or field name, ignoring
+// case; columns without field and fields without column are left alone.
+func Get[T any](is *Gsheet, readRange string, sprids ...string) ([]T, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	rows, err := is.GetValues(readRange, spreadsheetId)
+	if err != nil || len(rows) == 0 {
+		return []T{}, err
+	}
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	first := make([]string, len(rows[0]))
+	for c, h := range rows[0] {
+		first[c] = fmt.Sprint(h)
+	}
+	sheet, header, skip, err := is.maskHeader(spreadsheetId, readRange, first)
+	if err != nil {
+		return nil, err
+	}
+	if err = is.maskValues(sheet, header, rows[skip:], false); err != nil {
+		return nil, err
+	}
+	columns := make([]int, len(fields))
+	for i, f := range fields {
+		columns[i] = -1
+		for c, h := range rows[0] {
+			if strings.EqualFold(strings.TrimSpace(fmt.Sprint(h)), f.name) {
+				columns[i] = c
+				break
+			}
+		}
+	}
+	return decodeRows[T](rows[1:], fields, columns)
+}
+
+// GetPositional reads readRange into a slice of struct T, without header row:
+// column i goes to the i-th field.
+func GetPositional[T any](is *Gsheet, readRange string, sprids ...string) ([]T, error) {
+	rows, err := is.GetValues(readRange, sprids...)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]int, len(fields))
+	for i := range fields {
+		columns[i] = i
+	}
+	return decodeRows[T](rows, fields, columns)
+}
+
+func decodeRows[T any](rows [][]interface{}, fields []structField, columns []int) ([]T, error) {
+	ret := make([]T, 0, len(rows))
+	for r, row := range rows {
+		var item T
+		v := reflect.ValueOf(&item).Elem()
+		for i, f := range fields {
+			c := columns[i]
+			if c < 0 || c >= len(row) {
+				continue
+			}
+			if err := setValue(v.FieldByIndex(f.index), row[c]); err != nil {
+				return nil, fmt.Errorf("row %d, %s: %w", r+1, f.name, err)
+			}
+		}
+		ret = append(ret, item)
+	}
+	return ret, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// setValue stores a native cell value (string, float64 or bool) into v.
+// Empty cells leave v at its zero value.
+func setValue(v reflect.Value, cell interface{}) error {
+	if cell == nil || cell == "" {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(v.Type().Elem())
+		if err := setValue(p.Elem(), cell); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	s := fmt.Sprint(cell)
+	if v.Type() == timeType {
+		t, err := cellTime(cell)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := cellFloat(cell)
+		if err != nil {
+			return err
+		}
+		if f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", cell)
+		}
+		v.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := cellFloat(cell)
+		if err != nil {
+			return err
+		}
+		if f < 0 || f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an unsigned integer", cell)
+		}
+		v.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, err := cellFloat(cell)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(cell))
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func cellFloat(cell interface{}) (float64, error) {
+	if f, ok := cell.(float64); ok {
+		return f, nil
+	}
+	return strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(cell)), 64)
+}
+
+func cellTime(cell interface{}) (time.Time, error) {
+	if f, ok := cell.(float64); ok {
+		return SerialToTime(f), nil
+	}
+	s := strings.TrimSpace(fmt.Sprint(cell))
+	for _, layout := range TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can not parse time %q", s)
+}
@@ -0,0 +1,312 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// FreezeRows keeps the first n rows of the sheet with id sheetid visible
+// while scrolling; 0 unfreezes them.
+func (is *Gsheet) FreezeRows(sheetid int64, n int, sprids ...string) error {
+	return is.freeze(sheetid, &sheets.GridProperties{FrozenRowCount: int64(n), ForceSendFields: []string{"FrozenRowCount"}}, "gridProperties.frozenRowCount", n, sprids...)
+}
+
+// FreezeColumns keeps the first n columns of the sheet with id sheetid
+// visible while scrolling; 0 unfreezes them.
+func (is *Gsheet) FreezeColumns(sheetid int64, n int, sprids ...string) error {
+	return is.freeze(sheetid, &sheets.GridProperties{FrozenColumnCount: int64(n), ForceSendFields: []string{"FrozenColumnCount"}}, "gridProperties.frozenColumnCount", n, sprids...)
+}
+
+func (is *Gsheet) freeze(sheetid int64, grid *sheets.GridProperties, fields string, n int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if n < 0 {
+		return fmt.Errorf("negative frozen count %d", n)
+	}
+	if err := is.checkGrid(spreadsheetId, sheetid, NewRange("")); err != nil {
+		return err
+	}
+	_, err := is.Batch(spreadsheetId).UpdateSheetProperties(&sheets.SheetProperties{SheetId: sheetid, GridProperties: grid}, fields).Do()
+	return err
+}
+
+// dimensionRange spans the zero-based rows or columns start to end,
+// inclusive.
+func dimensionRange(sheetid int64, dimension string, start, end int) (*sheets.DimensionRange, error) {
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid %s span %d-%d", dimension, start, end)
+	}
+	return &sheets.DimensionRange{SheetId: sheetid, Dimension: dimension, StartIndex: int64(start), EndIndex: int64(end) + 1}, nil
+}
+
+// SetColumnWidth sets the zero-based columns startCol to endCol, inclusive,
+// of the sheet with id sheetid to pixels wide.
+func (is *Gsheet) SetColumnWidth(sheetid int64, startCol, endCol, pixels int, sprids ...string) error {
+	return is.setPixelSize(sheetid, DimensionColumns, startCol, endCol, pixels, sprids...)
+}
+
+// SetRowHeight sets the zero-based rows startRow to endRow, inclusive, of
+// the sheet with id sheetid to pixels high.
+func (is *Gsheet) SetRowHeight(sheetid int64, startRow, endRow, pixels int, sprids ...string) error {
+	return is.setPixelSize(sheetid, DimensionRows, startRow, endRow, pixels, sprids...)
+}
+
+func (is *Gsheet) setPixelSize(sheetid int64, dimension string, start, end, pixels int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if pixels <= 0 {
+		return fmt.Errorf("invalid size %d pixels", pixels)
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, end)
+	if err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, start, end)); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+		Range:      dr,
+		Properties: &sheets.DimensionProperties{PixelSize: int64(pixels)},
+		Fields:     "pixelSize",
+	}}).Do()
+	return err
+}
+
+// AutoResizeColumns fits the width of the zero-based columns startCol to
+// endCol, inclusive, of the sheet with id sheetid to their content.
+func (is *Gsheet) AutoResizeColumns(sheetid int64, startCol, endCol int, sprids ...string) error {
+	return is.autoResize(sheetid, DimensionColumns, startCol, endCol, sprids...)
+}
+
+// AutoResizeRows fits the height of the zero-based rows startRow to endRow,
+// inclusive, of the sheet with id sheetid to their content.
+func (is *Gsheet) AutoResizeRows(sheetid int64, startRow, endRow int, sprids ...string) error {
+	return is.autoResize(sheetid, DimensionRows, startRow, endRow, sprids...)
+}
+
+func (is *Gsheet) autoResize(sheetid int64, dimension string, start, end int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, end)
+	if err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, start, end)); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{Dimensions: dr}}).Do()
+	return err
+}
+
+// dimensionSpan is the range of the rows or columns start to end,
+// inclusive; end < 0 reaches the end of the sheet.
+func dimensionSpan(dimension string, start, end int) *Range {
+	if dimension == DimensionColumns {
+		return ColumnsRange("", start, end)
+	}
+	return RowsRange("", start, end)
+}
+
+// InsertRows inserts count empty rows before the zero-based row startIndex of
+// the sheet with id sheetid, shifting the rows below down. The new rows take
+// the format of the row above with inheritFromBefore, of the row below
+// otherwise.
+func (is *Gsheet) InsertRows(sheetid int64, startIndex, count int, inheritFromBefore bool, sprids ...string) error {
+	return is.insertDimension(sheetid, DimensionRows, startIndex, count, inheritFromBefore, sprids...)
+}
+
+// InsertColumns inserts count empty columns before the zero-based column
+// startIndex, shifting the columns to the right, like InsertRows.
+func (is *Gsheet) InsertColumns(sheetid int64, startIndex, count int, inheritFromBefore bool, sprids ...string) error {
+	return is.insertDimension(sheetid, DimensionColumns, startIndex, count, inheritFromBefore, sprids...)
+}
+
+func (is *Gsheet) insertDimension(sheetid int64, dimension string, start, count int, inheritFromBefore bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if count <= 0 {
+		return fmt.Errorf("invalid count %d", count)
+	}
+	if inheritFromBefore && start == 0 {
+		return fmt.Errorf("nothing before index 0 to inherit from")
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, start+count-1)
+	if err != nil {
+		return err
+	}
+	// everything from start on moves
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, start, -1)); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{InsertDimension: &sheets.InsertDimensionRequest{
+		Range:             dr,
+		InheritFromBefore: inheritFromBefore,
+	}}).Do()
+	return err
+}
+
+// MoveRows moves count rows from the zero-based startIndex of the sheet with
+// id sheetid to destIndex, with their formats, notes and validation.
+// destIndex counts rows as they are before the move, so moving the first row
+// below the third one takes destIndex 3.
+func (is *Gsheet) MoveRows(sheetid int64, startIndex, count, destIndex int, sprids ...string) error {
+	return is.moveDimension(sheetid, DimensionRows, startIndex, count, destIndex, sprids...)
+}
+
+// MoveColumns moves count columns from the zero-based startIndex to
+// destIndex like MoveRows.
+func (is *Gsheet) MoveColumns(sheetid int64, startIndex, count, destIndex int, sprids ...string) error {
+	return is.moveDimension(sheetid, DimensionColumns, startIndex, count, destIndex, sprids...)
+}
+
+func (is *Gsheet) moveDimension(sheetid int64, dimension string, start, count, dest int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if count <= 0 {
+		return fmt.Errorf("invalid count %d", count)
+	}
+	if dest < 0 || (dest > start && dest < start+count) {
+		return fmt.Errorf("can not move %s %d-%d to %d", dimension, start, start+count-1, dest)
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, start+count-1)
+	if err != nil {
+		return err
+	}
+	if dest == start || dest == start+count {
+		return nil
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, min(start, dest), max(start+count, dest)-1)); err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{MoveDimension: &sheets.MoveDimensionRequest{
+		Source:           dr,
+		DestinationIndex: int64(dest),
+		ForceSendFields:  []string{"DestinationIndex"},
+	}}).Do()
+	return err
+}
+
+// GroupRows adds an outline group over the zero-based rows start to end,
+// inclusive, of the sheet with id sheetid. Groups inside an existing group
+// nest one level deeper.
+func (is *Gsheet) GroupRows(sheetid int64, start, end int, sprids ...string) error {
+	return is.groupDimension(sheetid, DimensionRows, start, end, true, sprids...)
+}
+
+// GroupColumns adds an outline group over columns like GroupRows.
+func (is *Gsheet) GroupColumns(sheetid int64, start, end int, sprids ...string) error {
+	return is.groupDimension(sheetid, DimensionColumns, start, end, true, sprids...)
+}
+
+// UngroupRows removes one level of grouping from the rows start to end.
+func (is *Gsheet) UngroupRows(sheetid int64, start, end int, sprids ...string) error {
+	return is.groupDimension(sheetid, DimensionRows, start, end, false, sprids...)
+}
+
+// UngroupColumns removes one level of grouping from the columns start to end.
+func (is *Gsheet) UngroupColumns(sheetid int64, start, end int, sprids ...string) error {
+	return is.groupDimension(sheetid, DimensionColumns, start, end, false, sprids...)
+}
+
+func (is *Gsheet) groupDimension(sheetid int64, dimension string, start, end int, add bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, end)
+	if err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, start, end)); err != nil {
+		return err
+	}
+	req := &sheets.Request{DeleteDimensionGroup: &sheets.DeleteDimensionGroupRequest{Range: dr}}
+	if add {
+		req = &sheets.Request{AddDimensionGroup: &sheets.AddDimensionGroupRequest{Range: dr}}
+	}
+	_, err = is.Batch(spreadsheetId).Raw(req).Do()
+	return err
+}
+
+// SetRowGroupCollapsed collapses, or expands, the row group spanning exactly
+// the zero-based rows start to end, inclusive; the deepest such group when
+// groups are nested.
+func (is *Gsheet) SetRowGroupCollapsed(sheetid int64, start, end int, collapsed bool, sprids ...string) error {
+	return is.setGroupCollapsed(sheetid, DimensionRows, start, end, collapsed, sprids...)
+}
+
+// SetColumnGroupCollapsed collapses, or expands, a column group like
+// SetRowGroupCollapsed.
+func (is *Gsheet) SetColumnGroupCollapsed(sheetid int64, start, end int, collapsed bool, sprids ...string) error {
+	return is.setGroupCollapsed(sheetid, DimensionColumns, start, end, collapsed, sprids...)
+}
+
+func (is *Gsheet) setGroupCollapsed(sheetid int64, dimension string, start, end int, collapsed bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	dr, err := dimensionRange(sheetid, dimension, start, end)
+	if err != nil {
+		return err
+	}
+	if err = is.checkGrid(spreadsheetId, sheetid, dimensionSpan(dimension, start, end)); err != nil {
+		return err
+	}
+	groups, err := is.dimensionGroups(spreadsheetId, sheetid, dimension)
+	if err != nil {
+		return err
+	}
+	var group *sheets.DimensionGroup
+	for _, g := range groups {
+		if g.Range.StartIndex == dr.StartIndex && g.Range.EndIndex == dr.EndIndex && (group == nil || g.Depth > group.Depth) {
+			group = g
+		}
+	}
+	if group == nil {
+		return fmt.Errorf("no %s group spans %d-%d", dimension, start, end)
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{UpdateDimensionGroup: &sheets.UpdateDimensionGroupRequest{
+		DimensionGroup: &sheets.DimensionGroup{Range: group.Range, Depth: group.Depth, Collapsed: collapsed, ForceSendFields: []string{"Collapsed"}},
+		Fields:         "collapsed",
+	}}).Do()
+	return err
+}
+
+// dimensionGroups reads the row or column groups of the sheet with id
+// sheetid.
+func (is *Gsheet) dimensionGroups(spreadsheetId string, sheetid int64, dimension string) ([]*sheets.DimensionGroup, error) {
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.Spreadsheet
+	err := is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Get(spreadsheetId).Fields("sheets(properties.sheetId,rowGroups,columnGroups)").Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range resp.Sheets {
+		if s.Properties.SheetId != sheetid {
+			continue
+		}
+		if dimension == DimensionColumns {
+			return s.ColumnGroups, nil
+		}
+		return s.RowGroups, nil
+	}
+	return nil, fmt.Errorf("can not find sheetid %d", sheetid)
+}
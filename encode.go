@@ -0,0 +1,263 @@
+package gogsheet
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WriteTimeLayout is how time.Time fields are written; USER_ENTERED parses
+// it back into a date.
+var WriteTimeLayout = "2006-01-02 15:04:05"
+
+// encodeValue converts a struct field to a cell value: numbers and booleans
+// stay native, times use WriteTimeLayout, nil pointers and zero times are
+// empty.
+func encodeValue(v reflect.Value) (interface{}, error) {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		return encodeValue(v.Elem())
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(WriteTimeLayout), nil
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+// encodeStructs converts items to rows laid out by header, matching fields by
+// `gsheet` tag or field name ignoring case. Columns missing from header are
+// appended to it; the extended header is returned.
+func encodeStructs[T any](header []string, items []T) ([]string, [][]interface{}, error) {
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, nil, err
+	}
+	header = append([]string(nil), header...)
+	columns := make([]int, len(fields))
+	for i, f := range fields {
+		if columns[i] = headerColumn(header, f.name); columns[i] < 0 {
+			columns[i] = len(header)
+			header = append(header, f.name)
+		}
+	}
+	rows := make([][]interface{}, 0, len(items))
+	for r, item := range items {
+		v := reflect.ValueOf(item)
+		row := make([]interface{}, len(header))
+		for c := range row {
+			row[c] = ""
+		}
+		for i, f := range fields {
+			cell, err := encodeValue(v.FieldByIndex(f.index))
+			if err != nil {
+				return nil, nil, fmt.Errorf("item %d, %s: %w", r, f.name, err)
+			}
+			row[columns[i]] = cell
+		}
+		rows = append(rows, row)
+	}
+	return header, rows, nil
+}
+
+// headerColumn returns the column of name in header ignoring case and
+// surrounding spaces, -1 when missing.
+func headerColumn(header []string, name string) int {
+	name = strings.TrimSpace(name)
+	for c, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return c
+		}
+	}
+	return -1
+}
+
+// ensureColumns reads the header of sheetName and appends to it the names it
+// lacks. Unless add is set, a non-empty header lacking names is an error.
+func (is *Gsheet) ensureColumns(spreadsheetId, sheetName string, names []string, add bool) ([]string, error) {
+	resp, err := is.getValues(spreadsheetId, RowsRange(sheetName, 0, 0).String(), ReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+	header := []string{}
+	if rows := stringRows(resp.Values); len(rows) != 0 {
+		header = rows[0]
+	}
+	missing := []string{}
+	for _, name := range names {
+		if headerColumn(header, name) < 0 && headerColumn(missing, name) < 0 {
+			missing = append(missing, strings.TrimSpace(name))
+		}
+	}
+	if len(missing) == 0 {
+		return header, nil
+	}
+	if len(header) != 0 && !add {
+		return nil, fmt.Errorf("sheet %s has no column %s", sheetName, strings.Join(missing, ", "))
+	}
+	added := make([]interface{}, len(missing))
+	for i, h := range missing {
+		added[i] = h
+	}
+	if err = is.UpdateRangeWith([][]interface{}{added}, CellRange(sheetName, len(header), 0).String(), WriteOptions{ValueInputOption: Raw}, spreadsheetId); err != nil {
+		return nil, err
+	}
+	return append(header, missing...), nil
+}
+
+// structHeader makes sure the header of sheetName has the columns of T, see
+// ensureColumns, and encodes items along it with write masks applied.
+func structHeader[T any](is *Gsheet, spreadsheetId, sheetName string, items []T, addColumns bool) ([]string, [][]interface{}, error) {
+	fields, err := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	header, err := is.ensureColumns(spreadsheetId, sheetName, names, addColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+	header, rows, err := encodeStructs(header, items)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = is.maskValues(sheetName, header, rows, true); err != nil {
+		return nil, nil, err
+	}
+	return header, rows, nil
+}
+
+// WriteStructs replaces the rows below the header of sheetName with items,
+// the reverse of Get. Fields are mapped to header columns by `gsheet` tag or
+// field name; missing columns are added to the header. Write masks apply.
+func WriteStructs[T any](is *Gsheet, sheetName string, items []T, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	header, rows, err := structHeader(is, spreadsheetId, sheetName, items, true)
+	if err != nil {
+		return err
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	if err = is.ClearRange(OpenRange(sheetName, 0, 1, len(header)-1).String(), spreadsheetId); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return is.UpdateRange(rows, CellRange(sheetName, 0, 1).String(), spreadsheetId)
+}
+
+// AppendStructs appends items below the table of sheetName, mapping fields to
+// header columns like WriteStructs. An empty sheet gets a header first; when
+// the header lacks columns of T, they are added with addColumns and it is an
+// error otherwise.
+func AppendStructs[T any](is *Gsheet, sheetName string, items []T, addColumns bool, sprids ...string) (*AppendResult, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	header, rows, err := structHeader(is, spreadsheetId, sheetName, items, addColumns)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(header) == 0 {
+		return &AppendResult{FirstRow: -1, LastRow: -1}, nil
+	}
+	return is.AppendRows(rows, OpenRange(sheetName, 0, 0, len(header)-1).String(), spreadsheetId)
+}
+
+// AppendRecords appends records below the table of sheetName, placing each
+// value under the header column of its key whatever the column order; missing
+// keys leave blanks. Keys without column are an error unless addColumns is
+// set, then they are added to the header in sorted order so loosely
+// structured JSON can be ingested as is; an empty sheet gets a header either
+// way. Nested objects and arrays are written as JSON text.
+func (is *Gsheet) AppendRecords(sheetName string, records []map[string]interface{}, addColumns bool, sprids ...string) (*AppendResult, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, rec := range records {
+		for k := range rec {
+			if len(strings.TrimSpace(k)) == 0 {
+				return nil, fmt.Errorf("record with blank key")
+			}
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	header, err := is.ensureColumns(spreadsheetId, sheetName, keys, addColumns)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || len(header) == 0 {
+		return &AppendResult{FirstRow: -1, LastRow: -1}, nil
+	}
+	rows := make([][]interface{}, 0, len(records))
+	for _, rec := range records {
+		row := make([]interface{}, len(header))
+		for c := range row {
+			row[c] = ""
+		}
+		for k, v := range rec {
+			switch v.(type) {
+			case nil:
+				continue
+			case map[string]interface{}, []interface{}:
+				// nested JSON is kept as text
+				b, err := json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+				v = string(b)
+			}
+			c := headerColumn(header, k)
+			if c < 0 {
+				return nil, fmt.Errorf("column %s not in header of %s", k, sheetName)
+			}
+			row[c] = v
+		}
+		rows = append(rows, row)
+	}
+	if err = is.maskValues(sheetName, header, rows, true); err != nil {
+		return nil, err
+	}
+	return is.AppendRows(rows, OpenRange(sheetName, 0, 0, len(header)-1).String(), spreadsheetId)
+}
@@ -0,0 +1,22 @@
+package gogsheet
+
+import "testing"
+
+func TestHeaderColumn(t *testing.T) {
+	header := []string{"Id", " Name ", "x"}
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"id", 0},
+		{"name", 1},
+		{" x", 2},
+		{"x ", 2},
+		{"missing", -1},
+	}
+	for _, tt := range tests {
+		if got := headerColumn(header, tt.name); got != tt.want {
+			t.Errorf("headerColumn(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package gogsheet
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// CellAddress is the zero-based position of a cell.
+type CellAddress struct {
+	Sheet string
+	Col   int
+	Row   int
+}
+
+// String renders the address in A1 notation, e.g. "Data!C7".
+func (a CellAddress) String() string {
+	return CellRange(a.Sheet, a.Col, a.Row).String()
+}
+
+// FindCells returns the cells of sheetName whose displayed value is exactly
+// value, in row order. The sheet is read in chunks, so it never has to fit
+// in memory at once.
+func (is *Gsheet) FindCells(sheetName, value string, sprids ...string) ([]CellAddress, error) {
+	found := []CellAddress{}
+	err := is.StreamRange(NewRange(sheetName).String(), func(rowIndex int, row []string) error {
+		for c, v := range row {
+			if v == value {
+				found = append(found, CellAddress{Sheet: sheetName, Col: c, Row: rowIndex})
+			}
+		}
+		return nil
+	}, sprids...)
+	return found, err
+}
+
+// CellMatch is a cell found by SearchRange.
+type CellMatch struct {
+	CellAddress
+	Value string
+}
+
+// SearchRange returns the cells of readRange whose displayed value matches
+// matcher, a *regexp.Regexp or a func(string) bool, in row order. The range
+// is read in chunks.
+func (is *Gsheet) SearchRange(readRange string, matcher interface{}, sprids ...string) ([]CellMatch, error) {
+	var match func(string) bool
+	switch m := matcher.(type) {
+	case *regexp.Regexp:
+		match = m.MatchString
+	case func(string) bool:
+		match = m
+	default:
+		return nil, fmt.Errorf("matcher must be *regexp.Regexp or func(string) bool, not %T", matcher)
+	}
+	r, err := ParseRange(readRange)
+	if err != nil {
+		return nil, err
+	}
+	startCol := max(r.StartCol, 0)
+	found := []CellMatch{}
+	err = is.StreamRange(readRange, func(rowIndex int, row []string) error {
+		for c, v := range row {
+			if match(v) {
+				found = append(found, CellMatch{CellAddress{Sheet: r.Sheet, Col: startCol + c, Row: rowIndex}, v})
+			}
+		}
+		return nil
+	}, sprids...)
+	return found, err
+}
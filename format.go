@@ -0,0 +1,167 @@
+package gogsheet
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Number format patterns for SetNumberFormat.
+const (
+	NumberPattern   = "#,##0.00"
+	CurrencyPattern = `"$"#,##0.00`
+	PercentPattern  = "0.00%"
+	DatePattern     = "yyyy-mm-dd"
+	DateTimePattern = "yyyy-mm-dd hh:mm:ss"
+	DurationPattern = "[h]:mm:ss"
+)
+
+// formatLiterals matches the quoted text, escaped characters, [$...]
+// currency blocks, [Red] style color blocks and [>=100] style conditions of
+// a pattern, which do not tell its type. Elapsed time blocks such as [h] are
+// kept.
+var formatLiterals = regexp.MustCompile(`(?i)"[^"]*"|\\.|\[\$[^\]]*\]|\[(black|blue|cyan|green|magenta|red|white|yellow|color[0-9]+)\]|\[[<>=][^\]]*\]`)
+
+// numberFormatType guesses the format type the API wants along pattern.
+func numberFormatType(pattern string) string {
+	if strings.Contains(pattern, "[$") {
+		return "CURRENCY"
+	}
+	p := strings.ToLower(formatLiterals.ReplaceAllString(pattern, ""))
+	date := strings.ContainsAny(p, "yd")
+	clock := strings.ContainsAny(p, "hs")
+	switch {
+	case strings.Contains(p, "@"):
+		return "TEXT"
+	case strings.Contains(p, "%"):
+		return "PERCENT"
+	case date && clock:
+		return "DATE_TIME"
+	case date:
+		return "DATE"
+	case clock:
+		return "TIME"
+	case strings.ContainsAny(pattern, "$€£¥₫"):
+		return "CURRENCY"
+	case strings.Contains(p, "e+") || strings.Contains(p, "e-"):
+		return "SCIENTIFIC"
+	}
+	return "NUMBER"
+}
+
+// SetNumberFormat formats the numbers of rangeA1 with pattern, e.g.
+// "#,##0.00", "yyyy-mm-dd", "0.00%" or one of the *Pattern constants.
+// Values are not changed, only how they are shown.
+func (is *Gsheet) SetNumberFormat(rangeA1, pattern string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).FormatRange(sheetid, r, &sheets.CellFormat{
+		NumberFormat: &sheets.NumberFormat{Type: numberFormatType(pattern), Pattern: pattern},
+	}, "numberFormat").Do()
+	return err
+}
+
+// CellStyle describes the look of cells; only the fields set are changed by
+// FormatRange, so styles can be layered.
+type CellStyle struct {
+	Bold          *bool
+	Italic        *bool
+	Underline     *bool
+	Strikethrough *bool
+	FontFamily    string
+	FontSize      int
+	Foreground    *sheets.Color // text color
+	Background    *sheets.Color
+	// HorizontalAlignment is LEFT, CENTER or RIGHT, VerticalAlignment TOP,
+	// MIDDLE or BOTTOM and WrapStrategy OVERFLOW_CELL, CLIP or WRAP.
+	HorizontalAlignment string
+	VerticalAlignment   string
+	WrapStrategy        string
+}
+
+// Bool returns a pointer to b, for the flags of CellStyle.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// HexColor parses "#rrggbb" or "rrggbb", nil when s is not a color.
+func HexColor(s string) *sheets.Color {
+	var r, g, b uint8
+	if n, err := fmt.Sscanf(strings.TrimPrefix(s, "#"), "%02x%02x%02x", &r, &g, &b); err != nil || n != 3 {
+		return nil
+	}
+	return &sheets.Color{Red: float64(r) / 255, Green: float64(g) / 255, Blue: float64(b) / 255}
+}
+
+// compile returns the format of the style and its field mask relative to
+// userEnteredFormat.
+func (style CellStyle) compile() (*sheets.CellFormat, string) {
+	format := &sheets.CellFormat{TextFormat: &sheets.TextFormat{}}
+	fields := []string{}
+	flag := func(v *bool, dst *bool, name string) {
+		if v != nil {
+			*dst = *v
+			fields = append(fields, "textFormat."+name)
+		}
+	}
+	flag(style.Bold, &format.TextFormat.Bold, "bold")
+	flag(style.Italic, &format.TextFormat.Italic, "italic")
+	flag(style.Underline, &format.TextFormat.Underline, "underline")
+	flag(style.Strikethrough, &format.TextFormat.Strikethrough, "strikethrough")
+	if len(style.FontFamily) != 0 {
+		format.TextFormat.FontFamily = style.FontFamily
+		fields = append(fields, "textFormat.fontFamily")
+	}
+	if style.FontSize > 0 {
+		format.TextFormat.FontSize = int64(style.FontSize)
+		fields = append(fields, "textFormat.fontSize")
+	}
+	if style.Foreground != nil {
+		format.TextFormat.ForegroundColor = style.Foreground
+		fields = append(fields, "textFormat.foregroundColor")
+	}
+	if style.Background != nil {
+		format.BackgroundColor = style.Background
+		fields = append(fields, "backgroundColor")
+	}
+	if len(style.HorizontalAlignment) != 0 {
+		format.HorizontalAlignment = style.HorizontalAlignment
+		fields = append(fields, "horizontalAlignment")
+	}
+	if len(style.VerticalAlignment) != 0 {
+		format.VerticalAlignment = style.VerticalAlignment
+		fields = append(fields, "verticalAlignment")
+	}
+	if len(style.WrapStrategy) != 0 {
+		format.WrapStrategy = style.WrapStrategy
+		fields = append(fields, "wrapStrategy")
+	}
+	return format, strings.Join(fields, ",")
+}
+
+// FormatRange applies style to every cell of rangeA1 in one request, e.g.
+// FormatRange("Data!1:1", CellStyle{Bold: Bool(true), Background: HexColor("#d9d9d9")}).
+func (is *Gsheet) FormatRange(rangeA1 string, style CellStyle, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	format, fields := style.compile()
+	if len(fields) == 0 {
+		return nil
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).FormatRange(sheetid, r, format, fields).Do()
+	return err
+}
@@ -0,0 +1,33 @@
+package gogsheet
+
+import "testing"
+
+func TestNumberFormatType(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{NumberPattern, "NUMBER"},
+		{"#,##0.00;[Red]-#,##0.00", "NUMBER"},
+		{"[Color10]0;[RED](0)", "NUMBER"},
+		{"[>=1000]#,##0;0", "NUMBER"},
+		{"@", "TEXT"},
+		{`"Order "@`, "TEXT"},
+		{CurrencyPattern, "CURRENCY"},
+		{"[$€-2] #,##0.00", "CURRENCY"},
+		{PercentPattern, "PERCENT"},
+		{DatePattern, "DATE"},
+		{"dd/mm/yyyy;[Red]dd/mm/yyyy", "DATE"},
+		{DateTimePattern, "DATE_TIME"},
+		{"hh:mm", "TIME"},
+		{DurationPattern, "TIME"},
+		{"0.00E+00", "SCIENTIFIC"},
+		{`0 "days"`, "NUMBER"},
+		{`0\d`, "NUMBER"},
+	}
+	for _, tt := range tests {
+		if got := numberFormatType(tt.pattern); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gogsheet
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func checkFormula(formula string) error {
+	if !strings.HasPrefix(formula, "=") {
+		return fmt.Errorf("formula %q does not start with =", formula)
+	}
+	return nil
+}
+
+// SetFormula writes formula, which must start with "=", to cellAddress of
+// sheetName, e.g. "C2". It is always written as USER_ENTERED and never
+// sanitized.
+func (is *Gsheet) SetFormula(sheetName, cellAddress, formula string, sprids ...string) error {
+	if err := checkFormula(formula); err != nil {
+		return err
+	}
+	cell, err := sheetCell(sheetName, cellAddress)
+	if err != nil {
+		return err
+	}
+	opts := is.writeOptions
+	opts.ValueInputOption = UserEntered
+	return is.UpdateRangeWith([][]interface{}{{TrustedFormula(formula)}}, cell.String(), opts, sprids...)
+}
+
+// SetFormulaRange sets formula on every cell of rangeA1 in one request.
+// Relative references shift from cell to cell as with a fill, so
+// "=A2*B2" set on C2:C10 gives "=A3*B3" in C3.
+func (is *Gsheet) SetFormulaRange(rangeA1, formula string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if err := checkFormula(formula); err != nil {
+		return err
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r,
+		&sheets.CellData{UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula}}, "userEnteredValue").Do()
+	return err
+}
+
+// FormulaString quotes s as a string literal inside a formula.
+func FormulaString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// formulaRange quotes the sheet name of rangeA1 for use inside a formula,
+// so "My Sheet!A:B" becomes "'My Sheet'!A:B"; the cell part, with its $
+// anchors, is kept as written.
+func formulaRange(rangeA1 string) string {
+	i := strings.LastIndex(rangeA1, "!")
+	if i < 0 {
+		return rangeA1
+	}
+	sheet := rangeA1[:i]
+	if len(sheet) >= 2 && sheet[0] == '\'' && sheet[len(sheet)-1] == '\'' {
+		sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	return QuoteSheetName(sheet) + rangeA1[i:]
+}
+
+// VLookup builds =VLOOKUP(searchKey, rangeA1, index, FALSE), or TRUE when
+// exact is false. searchKey is an expression such as a cell reference; quote
+// literals with FormulaString. index is one-based as in the spreadsheet. The
+// sheet name of rangeA1 is quoted when needed.
+func VLookup(searchKey, rangeA1 string, index int, exact bool) string {
+	return fmt.Sprintf("=VLOOKUP(%s,%s,%d,%s)", searchKey, formulaRange(rangeA1), index, strings.ToUpper(fmt.Sprint(!exact)))
+}
+
+// ImportRange builds =IMPORTRANGE for a spreadsheet URL or id and a range of
+// it. The target spreadsheet has to grant access once in the UI.
+func ImportRange(spreadsheetUrl, rangeA1 string) string {
+	return fmt.Sprintf("=IMPORTRANGE(%s,%s)", FormulaString(spreadsheetUrl), FormulaString(formulaRange(rangeA1)))
+}
+
+// Query builds =QUERY(rangeA1, query, headers); headers < 0 lets the
+// spreadsheet guess the number of header rows. The sheet name of rangeA1 is
+// quoted when needed.
+func Query(rangeA1, query string, headers int) string {
+	if headers < 0 {
+		return fmt.Sprintf("=QUERY(%s,%s)", formulaRange(rangeA1), FormulaString(query))
+	}
+	return fmt.Sprintf("=QUERY(%s,%s,%d)", formulaRange(rangeA1), FormulaString(query), headers)
+}
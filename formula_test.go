@@ -0,0 +1,21 @@
+package gogsheet
+
+import "testing"
+
+func TestFormulaBuilders(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{VLookup("A2", "My Sheet!A:B", 2, true), "=VLOOKUP(A2,'My Sheet'!A:B,2,FALSE)"},
+		{VLookup("A2", "'My Sheet'!$A$1:$B$9", 2, false), "=VLOOKUP(A2,'My Sheet'!$A$1:$B$9,2,TRUE)"},
+		{VLookup("A2", "Data!A:B", 2, true), "=VLOOKUP(A2,Data!A:B,2,FALSE)"},
+		{VLookup("A2", "Prices", 2, true), "=VLOOKUP(A2,Prices,2,FALSE)"},
+		{Query("Bob's!A:C", "select A", 1), `=QUERY('Bob''s'!A:C,"select A",1)`},
+		{ImportRange("id", "My Sheet!A1:C"), `=IMPORTRANGE("id","'My Sheet'!A1:C")`},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s, want %s", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package gogsheet
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// GenOptions configures GenerateCode.
+type GenOptions struct {
+	Package    string // package clause of the generated file, default "models"
+	TypeName   string // struct name, default derived from the sheet name
+	SampleRows int    // rows inspected to infer column types, default 200
+}
+
+// GenField is one inferred column of a generated struct.
+type GenField struct {
+	Name   string // Go field name
+	Column string // header text
+	Type   string // Go type: string, int64, float64, bool or time.Time
+}
+
+var dateLikeRegexp = regexp.MustCompile(`^\d{1,4}[-/.]\d{1,2}[-/.]\d{1,4}`)
+
+// InferFields inspects the header row and up to sampleRows data rows of
+// sheetName and infers a Go field per column.
+func (is *Gsheet) InferFields(sheetName string, sampleRows int, sprids ...string) ([]GenField, error) {
+	if sampleRows <= 0 {
+		sampleRows = 200
+	}
+	readRange := RowsRange(sheetName, 0, sampleRows).String()
+	values, err := is.GetValues(readRange, sprids...)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: sheet %s has no header row", ErrNoData, sheetName)
+	}
+	formatted, err := is.GetValueRangeWith(readRange, ReadOptions{ValueRenderOption: FormattedValue}, sprids...)
+	if err != nil {
+		return nil, err
+	}
+	fields := []GenField{}
+	used := map[string]bool{}
+	for c, h := range values[0] {
+		column := strings.TrimSpace(fmt.Sprint(h))
+		name := goIdentifier(column)
+		if len(name) == 0 {
+			name = "Column" + ColumnLetter(c)
+		}
+		for base, n := name, 2; used[name]; n++ {
+			name = fmt.Sprintf("%s%d", base, n)
+		}
+		used[name] = true
+		fields = append(fields, GenField{Name: name, Column: column, Type: inferColumnType(values[1:], formatted[1:], c)})
+	}
+	return fields, nil
+}
+
+func inferColumnType(values [][]interface{}, formatted [][]string, c int) string {
+	kind := ""
+	for r, row := range values {
+		if c >= len(row) || row[c] == nil || row[c] == "" {
+			continue
+		}
+		t := "string"
+		switch v := row[c].(type) {
+		case bool:
+			t = "bool"
+		case float64:
+			t = "float64"
+			if r < len(formatted) && c < len(formatted[r]) && dateLikeRegexp.MatchString(formatted[r][c]) {
+				t = "time.Time"
+			} else if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				t = "int64"
+			}
+		}
+		switch {
+		case len(kind) == 0 || kind == t:
+			kind = t
+		case kind == "int64" && t == "float64", kind == "float64" && t == "int64":
+			kind = "float64"
+		default:
+			return "string"
+		}
+	}
+	if len(kind) == 0 {
+		return "string"
+	}
+	return kind
+}
+
+// goIdentifier turns header text like "order id" into "OrderID"-style names.
+func goIdentifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var b strings.Builder
+	for _, w := range words {
+		if u := strings.ToUpper(w); u == "ID" || u == "URL" || u == "API" || u == "UUID" {
+			b.WriteString(u)
+			continue
+		}
+		rs := []rune(strings.ToLower(w))
+		rs[0] = unicode.ToUpper(rs[0])
+		b.WriteString(string(rs))
+	}
+	name := b.String()
+	if len(name) != 0 && !unicode.IsLetter([]rune(name)[0]) {
+		name = "F" + name
+	}
+	return name
+}
+
+// GenerateCode generates a Go source file with a struct matching sheetName
+// and ReadX/WriteX functions bound to that sheet, to keep application models
+// in sync with an evolving spreadsheet (see cmd/gogsheet-gen).
+func (is *Gsheet) GenerateCode(sheetName string, opts GenOptions, sprids ...string) ([]byte, error) {
+	fields, err := is.InferFields(sheetName, opts.SampleRows, sprids...)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Package) == 0 {
+		opts.Package = "models"
+	}
+	if len(opts.TypeName) == 0 {
+		opts.TypeName = goIdentifier(strings.TrimSuffix(sheetName, "s"))
+		if len(opts.TypeName) == 0 {
+			opts.TypeName = "Row"
+		}
+	}
+	return renderCode(sheetName, opts, fields)
+}
+
+func renderCode(sheetName string, opts GenOptions, fields []GenField) ([]byte, error) {
+	t := opts.TypeName
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gogsheet-gen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", opts.Package)
+	for _, f := range fields {
+		if f.Type == "time.Time" {
+			b.WriteString("\t\"time\"\n\n")
+			break
+		}
+	}
+	b.WriteString("\t\"github.com/sonnt85/gogsheet\"\n)\n\n")
+	fmt.Fprintf(&b, "// %sSheet is the sheet %s is read from and written to.\nconst %sSheet = %q\n\n", t, t, t, sheetName)
+	fmt.Fprintf(&b, "// %s is one row of sheet %s.\ntype %s struct {\n", t, sheetName, t)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\t%s %s `gsheet:%q`\n", f.Name, f.Type, f.Column)
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "// Read%ss reads every row of %sSheet below the header.\n", t, t)
+	fmt.Fprintf(&b, "func Read%ss(g *gogsheet.Gsheet, sprids ...string) ([]%s, error) {\n", t, t)
+	fmt.Fprintf(&b, "\treturn gogsheet.Get[%s](g, gogsheet.NewRange(%sSheet).String(), sprids...)\n}\n\n", t, t)
+	fmt.Fprintf(&b, "// Write%ss replaces the content of %sSheet with a header row and items.\n", t, t)
+	fmt.Fprintf(&b, "func Write%ss(g *gogsheet.Gsheet, items []%s, sprids ...string) error {\n", t, t)
+	b.WriteString("\trows := [][]interface{}{{")
+	for i, f := range fields {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%q", f.Column)
+	}
+	b.WriteString("}}\n\tfor _, it := range items {\n\t\trows = append(rows, []interface{}{")
+	for i, f := range fields {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		if f.Type == "time.Time" {
+			fmt.Fprintf(&b, "it.%s.Format(\"2006-01-02 15:04:05\")", f.Name)
+		} else {
+			fmt.Fprintf(&b, "it.%s", f.Name)
+		}
+	}
+	b.WriteString("})\n\t}\n")
+	fmt.Fprintf(&b, "\tif err := g.ClearRange(gogsheet.NewRange(%sSheet).String(), sprids...); err != nil {\n\t\treturn err\n\t}\n", t)
+	fmt.Fprintf(&b, "\treturn g.UpdateRange(rows, gogsheet.CellRange(%sSheet, 0, 0).String(), sprids...)\n}\n", t)
+	return format.Source(b.Bytes())
+}
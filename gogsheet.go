@@ -3,19 +3,29 @@ package gogsheet
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
+	"google.golang.org/api/script/v1"
 	"google.golang.org/api/sheets/v4"
 )
 
+// OAuthScopes are requested when authorizing with an oauth2 token. Drive based
+// features (registry, cloning) also need drive.DriveScope; if modifying these
+// scopes, delete your previously saved token.json.
+var OAuthScopes = []string{sheets.SpreadsheetsScope}
+
 // Retrieve a token, saves the token, then returns the generated client.
 func getClient(config *oauth2.Config, tokFile string) *http.Client {
 	// The file token.json stores the user's access and refresh tokens, and is
@@ -73,11 +83,30 @@ func saveToken(path string, token *oauth2.Token) {
 
 type Gsheet struct {
 	mutex                      sync.Mutex
+	locks                      map[string]*sync.RWMutex
 	TokenOauth2_Or_Credentials string
 	oauthPath                  string
 	spreadsheetId              string
+	trim                       TrimPolicy
+	readOptions                ReadOptions
+	writeOptions               WriteOptions
+	retryPolicy                RetryPolicy
+	role                       Role
+	guards                     []guardRule
+	recorder                   *recorder
+	locales                    map[string]*SpreadsheetInfo
+	safeBatch                  bool
+	tuner                      *chunkTuner
+	life                       *lifecycle
+	emptyResults               bool
+	cache                      *valueCache
+	sanitize                   bool
+	masks                      []maskRule
 	*sheets.Service
-	ctx context.Context
+	clientOption option.ClientOption
+	drive        *drive.Service
+	script       *script.Service
+	ctx          context.Context
 }
 
 func New(oauth2_token_path, credentials_oauth_path, spreadsheetid string) (*Gsheet, error) {
@@ -86,12 +115,16 @@ func New(oauth2_token_path, credentials_oauth_path, spreadsheetid string) (*Gshe
 		TokenOauth2_Or_Credentials: oauth2_token_path,
 		oauthPath:                  credentials_oauth_path,
 		mutex:                      sync.Mutex{},
+		locks:                      map[string]*sync.RWMutex{},
 		spreadsheetId:              spreadsheetid,
+		retryPolicy:                DefaultRetryPolicy,
+		life:                       newLifecycle(),
 	}
 
 	is.ctx = context.Background()
 	if len(oauth2_token_path) == 0 {
-		is.Service, err = sheets.NewService(is.ctx, option.WithServiceAccountFile(credentials_oauth_path))
+		is.clientOption = option.WithServiceAccountFile(credentials_oauth_path)
+		is.Service, err = sheets.NewService(is.ctx, is.clientOption)
 		// is.Service, err = sheets.NewService(is.ctx, option.WithCredentialsFile(credentials_oauth_path))
 	} else {
 		is.TokenOauth2_Or_Credentials = credentials_oauth_path
@@ -102,12 +135,13 @@ func New(oauth2_token_path, credentials_oauth_path, spreadsheetid string) (*Gshe
 		}
 		// If modifying these scopes, delete your previously saved token.json.
 		config := new(oauth2.Config)
-		config, err = google.ConfigFromJSON(b, sheets.SpreadsheetsScope)
+		config, err = google.ConfigFromJSON(b, OAuthScopes...)
 		if err != nil {
 			return nil, err
 		}
 		client := getClient(config, is.TokenOauth2_Or_Credentials)
-		is.Service, err = sheets.NewService(is.ctx, option.WithHTTPClient(client))
+		is.clientOption = option.WithHTTPClient(client)
+		is.Service, err = sheets.NewService(is.ctx, is.clientOption)
 	}
 	if err != nil {
 		return nil, err
@@ -116,35 +150,155 @@ func New(oauth2_token_path, credentials_oauth_path, spreadsheetid string) (*Gshe
 
 }
 
+// driveService returns the Drive API client sharing the sheets credentials.
+func (is *Gsheet) driveService() (*drive.Service, error) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	if is.drive == nil {
+		srv, err := drive.NewService(is.ctx, is.clientOption)
+		if err != nil {
+			return nil, err
+		}
+		is.drive = srv
+	}
+	return is.drive, nil
+}
+
+// spreadsheetLock returns the lock guarding one spreadsheet, so operations on
+// different spreadsheets never wait for each other.
+func (is *Gsheet) spreadsheetLock(spreadsheetId string) *sync.RWMutex {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	l, ok := is.locks[spreadsheetId]
+	if !ok {
+		l = new(sync.RWMutex)
+		is.locks[spreadsheetId] = l
+	}
+	return l
+}
+
+// SetSafeBatch switches locking to favour structural safety: structural
+// changes (add/delete/move sheets and dimensions) run alone per spreadsheet,
+// value writes run concurrently with each other but never during a structural
+// change, and value reads take no lock at all. Off by default, where reads
+// share a lock and every mutation is exclusive.
+func (is *Gsheet) SetSafeBatch(on bool) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.safeBatch = on
+}
+
+func (is *Gsheet) isSafeBatch() bool {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	return is.safeBatch
+}
+
+// rlock takes the lock of a value read and returns its unlock func.
+func (is *Gsheet) rlock(spreadsheetId string) func() {
+	if is.isSafeBatch() {
+		return func() {}
+	}
+	l := is.spreadsheetLock(spreadsheetId)
+	l.RLock()
+	return l.RUnlock
+}
+
+// wlock takes the lock of a value write and returns its unlock func.
+func (is *Gsheet) wlock(spreadsheetId string) func() {
+	l := is.spreadsheetLock(spreadsheetId)
+	if is.isSafeBatch() {
+		l.RLock()
+		return l.RUnlock
+	}
+	l.Lock()
+	return l.Unlock
+}
+
+// lock takes the exclusive lock of a structural change and returns its unlock func.
+func (is *Gsheet) lock(spreadsheetId string) func() {
+	l := is.spreadsheetLock(spreadsheetId)
+	l.Lock()
+	return l.Unlock
+}
+
 func (is *Gsheet) UpdateSpreadsheetId(spreadsheetid string) {
 	is.spreadsheetId = spreadsheetid
 }
 
+// SetTrimPolicy sets how trailing blank rows/cells are cleaned from GetValueRange(s) results.
+func (is *Gsheet) SetTrimPolicy(policy TrimPolicy) {
+	is.trim = policy
+}
+
 func (is *Gsheet) GetValueRange(readRange string, sprids ...string) ([][]string, error) {
+	return is.GetValueRangeWith(readRange, is.readOptions, sprids...)
+}
+
+// GetValueRangeWith reads readRange rendered per opts, e.g. ReadOptions{ValueRenderOption: Formula}.
+func (is *Gsheet) GetValueRangeWith(readRange string, opts ReadOptions, sprids ...string) ([][]string, error) {
 	spreadsheetId := is.spreadsheetId
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	resp, err := is.Service.Spreadsheets.Values.Get(spreadsheetId, readRange).Do()
+	c := is.cache
+	var key string
+	var gen uint64
+	if c != nil {
+		var rows [][]string
+		var ok bool
+		key = cacheKey(spreadsheetId, readRange, opts, is.trim)
+		if rows, ok, gen = c.lookup(key); ok {
+			if len(rows) == 0 {
+				return rows, is.noData()
+			}
+			return rows, nil
+		}
+	}
+	resp, err := is.getValues(spreadsheetId, readRange, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Values) == 0 {
-		return nil, fmt.Errorf("no data found")
-	} else {
-		ret := [][]string{}
-		for _, row := range resp.Values {
-			col := []string{}
-			for _, s := range row {
-				col = append(col, fmt.Sprint(s))
-			}
-			ret = append(ret, col)
+	ret := [][]string{}
+	for _, row := range resp.Values {
+		col := []string{}
+		for _, s := range row {
+			col = append(col, fmt.Sprint(s))
 		}
-		return ret, nil
+		ret = append(ret, col)
+	}
+	ret = TrimRows(ret, is.trim)
+	if c != nil {
+		c.store(key, spreadsheetId, readRange, ret, gen)
 	}
+	if len(ret) == 0 {
+		return [][]string{}, is.noData()
+	}
+	return ret, nil
+}
+
+// getValues is the raw values.get call shared by the read methods.
+func (is *Gsheet) getValues(spreadsheetId, readRange string, opts ReadOptions) (resp *sheets.ValueRange, err error) {
+	if err = is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	err = is.retry(true, func() (err error) {
+		call := is.Service.Spreadsheets.Values.Get(spreadsheetId, readRange)
+		if len(opts.ValueRenderOption) != 0 {
+			call.ValueRenderOption(opts.ValueRenderOption)
+		}
+		if len(opts.DateTimeRenderOption) != 0 {
+			call.DateTimeRenderOption(opts.DateTimeRenderOption)
+		}
+		if len(opts.MajorDimension) != 0 {
+			call.MajorDimension(opts.MajorDimension)
+		}
+		resp, err = call.Do()
+		return err
+	})
+	return resp, err
 }
 
 func (is *Gsheet) GetValueCell(sheetname, cellAddress string, sprids ...string) (string, error) {
@@ -164,22 +318,25 @@ func (is *Gsheet) GetValueCell(sheetname, cellAddress string, sprids ...string)
 }
 
 func (is *Gsheet) GetValueRanges(readRanges []string, sprids ...string) (map[string][][]string, error) {
+	return is.GetValueRangesWith(readRanges, is.readOptions, sprids...)
+}
+
+// GetValueRangesWith reads readRanges rendered per opts.
+func (is *Gsheet) GetValueRangesWith(readRanges []string, opts ReadOptions, sprids ...string) (map[string][][]string, error) {
 	spreadsheetId := is.spreadsheetId
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	resp, err := is.Service.Spreadsheets.Values.BatchGet(spreadsheetId).Ranges(readRanges...).Do()
+	valueRanges, err := is.batchGetValues(spreadsheetId, readRanges, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.ValueRanges) == 0 {
-		return nil, fmt.Errorf("no data found")
+	if len(valueRanges) == 0 {
+		return map[string][][]string{}, is.noData()
 	} else {
 		ret := map[string][][]string{}
-		for _, valuerange := range resp.ValueRanges {
+		for _, valuerange := range valueRanges {
 			retRange := [][]string{}
 			for _, row := range valuerange.Values {
 				col := []string{}
@@ -188,58 +345,138 @@ func (is *Gsheet) GetValueRanges(readRanges []string, sprids ...string) (map[str
 				}
 				retRange = append(retRange, col)
 			}
-			ret[valuerange.Range] = retRange
+			ret[valuerange.Range] = TrimRows(retRange, is.trim)
 		}
 		return ret, nil
 	}
 }
 
+// batchGetValues is the raw values.batchGet call; value ranges come back in
+// the order of readRanges.
+func (is *Gsheet) batchGetValues(spreadsheetId string, readRanges []string, opts ReadOptions) ([]*sheets.ValueRange, error) {
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.BatchGetValuesResponse
+	err := is.retry(true, func() (err error) {
+		call := is.Service.Spreadsheets.Values.BatchGet(spreadsheetId).Ranges(readRanges...)
+		if len(opts.ValueRenderOption) != 0 {
+			call.ValueRenderOption(opts.ValueRenderOption)
+		}
+		if len(opts.DateTimeRenderOption) != 0 {
+			call.DateTimeRenderOption(opts.DateTimeRenderOption)
+		}
+		if len(opts.MajorDimension) != 0 {
+			call.MajorDimension(opts.MajorDimension)
+		}
+		resp, err = call.Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.ValueRanges, nil
+}
+
 func (is *Gsheet) UpdateRanges(rowsArray [][][]interface{}, rangeData []string, sprids ...string) (err error) {
+	return is.UpdateRangesWith(rowsArray, rangeData, is.writeOptions, sprids...)
+}
+
+// UpdateRangesWith is UpdateRanges with values interpreted per opts, e.g.
+// WriteOptions{ValueInputOption: Raw}.
+func (is *Gsheet) UpdateRangesWith(rowsArray [][][]interface{}, rangeData []string, opts WriteOptions, sprids ...string) (err error) {
 	spreadsheetId := is.spreadsheetId
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	// Modify this to your Needs
-	batchUpdateValuesRequest := &sheets.BatchUpdateValuesRequest{
-		ValueInputOption: "USER_ENTERED",
-	}
-
 	if len(rowsArray) != len(rangeData) {
 		return fmt.Errorf("rowsArray and rangeData need same len")
 	}
+	if err = is.checkRanges(spreadsheetId, rangeData...); err != nil {
+		return err
+	}
+	limit, key := is.writeChunk(spreadsheetId, opts)
+	chunks := chunkUpdates(rowsArray, is.resolveSplit(spreadsheetId, rowsArray, rangeData, opts, limit), opts, limit)
+	// chunks are sent in order; a failed chunk does not stop the others
+	errs := []error{}
+	for _, c := range chunks {
+		start := time.Now()
+		err = is.updateRanges(spreadsheetId, c.rowsArray, c.ranges, opts)
+		if tuner := is.tuner; tuner != nil && len(key) != 0 && is.recorder == nil {
+			tuner.observe(key, c.rows, time.Since(start), err)
+		}
+		if len(chunks) == 1 {
+			return err
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", strings.Join(c.ranges, ","), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateRanges sends rowsArray in one values.batchUpdate call.
+func (is *Gsheet) updateRanges(spreadsheetId string, rowsArray [][][]interface{}, rangeData []string, opts WriteOptions) error {
+	// Modify this to your Needs
+	batchUpdateValuesRequest := &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: opts.valueInputOption(),
+	}
 	for i, rows := range rowsArray {
 		batchUpdateValuesRequest.Data = append(batchUpdateValuesRequest.Data, &sheets.ValueRange{
-			Range:  rangeData[i],
-			Values: rows,
+			Range:          rangeData[i],
+			Values:         is.prepareRows(rows, opts),
+			MajorDimension: opts.majorDimension(),
 		})
 	}
 
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.batchUpdate", SpreadsheetId: spreadsheetId,
+			ValueInputOption: batchUpdateValuesRequest.ValueInputOption, ValueRanges: batchUpdateValuesRequest.Data})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId, rangeData...)
 	// Do a batch update at once
-	_, err = is.Spreadsheets.Values.BatchUpdate(spreadsheetId, batchUpdateValuesRequest).Do()
-	return err
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.BatchUpdate(spreadsheetId, batchUpdateValuesRequest).Do()
+		return err
+	})
 }
 
 func (is *Gsheet) UpdateRange(rows [][]interface{}, rangeData string, sprids ...string) (err error) {
+	return is.UpdateRangeWith(rows, rangeData, is.writeOptions, sprids...)
+}
+
+// UpdateRangeWith is UpdateRange with values interpreted per opts.
+func (is *Gsheet) UpdateRangeWith(rows [][]interface{}, rangeData string, opts WriteOptions, sprids ...string) (err error) {
 	spreadsheetId := is.spreadsheetId
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
+	if limit, _ := is.writeChunk(spreadsheetId, opts); limit > 0 && len(rows) > limit && opts.majorDimension() == DimensionRows {
+		return is.UpdateRangesWith([][][]interface{}{rows}, []string{rangeData}, opts, spreadsheetId)
+	}
+	if err = is.checkRanges(spreadsheetId, rangeData); err != nil {
+		return err
+	}
 	valueRange := &sheets.ValueRange{
-		Values:         rows,
-		MajorDimension: "ROWS",
+		Values:         is.prepareRows(rows, opts),
+		MajorDimension: opts.majorDimension(),
 	}
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.update", SpreadsheetId: spreadsheetId, Range: rangeData,
+			ValueInputOption: opts.valueInputOption(), ValueRanges: []*sheets.ValueRange{valueRange}})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId, rangeData)
 	// Do a batch update at once
-	_, err = is.Spreadsheets.Values.Update(spreadsheetId, rangeData, valueRange).ValueInputOption("USER_ENTERED").Do()
-	return err
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.Update(spreadsheetId, rangeData, valueRange).ValueInputOption(opts.valueInputOption()).Do()
+		return err
+	})
 }
 
-func (is *Gsheet) DeleteRange(sheetid int64, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex int64, sprids ...string) (err error) {
-	spreadsheetId := is.spreadsheetId
-	if len(sprids) != 0 {
-		spreadsheetId = sprids[0]
-	}
+func deleteRangeRequest(sheetid int64, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex int64) *sheets.DeleteRangeRequest {
 	gridrange := &sheets.DeleteRangeRequest{
 		ShiftDimension: "ROWS",
 		Range: &sheets.GridRange{
@@ -264,13 +501,24 @@ func (is *Gsheet) DeleteRange(sheetid int64, startRowIndex, startColumnIndex, en
 	if endColumnIndex >= 0 {
 		gridrange.Range.EndColumnIndex = endColumnIndex
 	}
+	return gridrange
+}
+
+func (is *Gsheet) DeleteRange(sheetid int64, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex int64, sprids ...string) (err error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	gridrange := deleteRangeRequest(sheetid, startRowIndex, startColumnIndex, endRowIndex, endColumnIndex)
+	if err = is.checkGrid(spreadsheetId, sheetid, gridToRange("", gridrange.Range)); err != nil {
+		return err
+	}
 	rq := &sheets.BatchUpdateSpreadsheetRequest{
 		IncludeSpreadsheetInResponse: true,
 		Requests:                     []*sheets.Request{&sheets.Request{DeleteRange: gridrange}},
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	_, err = is.Spreadsheets.BatchUpdate(spreadsheetId, rq).Do()
+	// deleting shifts cells, so it is never retried
+	_, err = is.batchUpdate(spreadsheetId, rq, false)
 	return err
 }
 
@@ -279,10 +527,18 @@ func (is *Gsheet) ClearRange(rangeA1 string, sprids ...string) (err error) {
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	_, err = is.Spreadsheets.Values.Clear(spreadsheetId, rangeA1, new(sheets.ClearValuesRequest)).Do()
-	return err
+	if err = is.checkRanges(spreadsheetId, rangeA1); err != nil {
+		return err
+	}
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.clear", SpreadsheetId: spreadsheetId, Range: rangeA1})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId, rangeA1)
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.Clear(spreadsheetId, rangeA1, new(sheets.ClearValuesRequest)).Do()
+		return err
+	})
 }
 
 func (is *Gsheet) ClearRanges(sheetid int64, rangesA1 []string, sprids ...string) (err error) {
@@ -290,27 +546,152 @@ func (is *Gsheet) ClearRanges(sheetid int64, rangesA1 []string, sprids ...string
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	_, err = is.Spreadsheets.Values.BatchClear(spreadsheetId, &sheets.BatchClearValuesRequest{Ranges: rangesA1}).Do()
-	return err
+	if err = is.checkRanges(spreadsheetId, rangesA1...); err != nil {
+		return err
+	}
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.batchClear", SpreadsheetId: spreadsheetId, Ranges: rangesA1})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId, rangesA1...)
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.BatchClear(spreadsheetId, &sheets.BatchClearValuesRequest{Ranges: rangesA1}).Do()
+		return err
+	})
+}
+
+// AppendResult tells where AppendRows wrote, so the new rows can be
+// referenced or formatted without reading the sheet again.
+type AppendResult struct {
+	UpdatedRange string // A1 range the rows were written to
+	TableRange   string // table the rows were appended after, empty when unknown
+	FirstRow     int    // zero-based sheet row of the first appended row, -1 when unknown
+	LastRow      int    // zero-based sheet row of the last appended row, -1 when unknown
+	UpdatedRows  int
 }
 
-func (is *Gsheet) AppendRows(rows [][]interface{}, rangeData string, sprids ...string) (err error) {
+func newAppendResult(resp *sheets.AppendValuesResponse) *AppendResult {
+	ret := &AppendResult{TableRange: resp.TableRange, FirstRow: -1, LastRow: -1}
+	if u := resp.Updates; u != nil {
+		ret.UpdatedRange, ret.UpdatedRows = u.UpdatedRange, int(u.UpdatedRows)
+		if r, err := ParseRange(u.UpdatedRange); err == nil {
+			ret.FirstRow, ret.LastRow = r.StartRow, r.EndRow
+		}
+	}
+	return ret
+}
+
+func (is *Gsheet) AppendRows(rows [][]interface{}, rangeData string, sprids ...string) (*AppendResult, error) {
+	return is.AppendRowsWith(rows, rangeData, is.writeOptions, sprids...)
+}
+
+// AppendRowsWith is AppendRows with values interpreted and inserted per opts,
+// e.g. WriteOptions{InsertDataOption: InsertRows} to keep a totals row below
+// the table.
+func (is *Gsheet) AppendRowsWith(rows [][]interface{}, rangeData string, opts WriteOptions, sprids ...string) (ret *AppendResult, err error) {
 	spreadsheetId := is.spreadsheetId
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
+	if err = is.checkRanges(spreadsheetId, rangeData); err != nil {
+		return nil, err
+	}
 	// Modify this to your Needs
+	rows = is.prepareRows(rows, opts)
 	valueRange := &sheets.ValueRange{
-		Values: rows,
-		// MajorDimension: "ROWS",
+		Values:         rows,
+		MajorDimension: opts.majorDimension(),
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.append", SpreadsheetId: spreadsheetId, Range: rangeData,
+			ValueInputOption: opts.valueInputOption(), InsertDataOption: opts.insertDataOption(), ValueRanges: []*sheets.ValueRange{valueRange}})
+		return &AppendResult{FirstRow: -1, LastRow: -1}, nil
+	}
+	defer is.invalidate(spreadsheetId, NewRange(sheetOf(rangeData)).String())
 	// Do a value append at once
-	_, err = is.Spreadsheets.Values.Append(spreadsheetId, rangeData, valueRange).ValueInputOption("USER_ENTERED").Do()
-	return err
+	doAppend := func() error {
+		resp, err := is.Spreadsheets.Values.Append(spreadsheetId, rangeData, valueRange).ValueInputOption(opts.valueInputOption()).
+			InsertDataOption(opts.insertDataOption()).Do()
+		if err == nil {
+			ret = newAppendResult(resp)
+		}
+		return err
+	}
+	policy := is.retryPolicy
+	var tokens []string
+	if policy.RetryAppends && policy.DedupeColumn != nil && *policy.DedupeColumn >= 0 && len(rows) != 0 && opts.majorDimension() != DimensionColumns {
+		var tokened [][]interface{}
+		if tokened, tokens, err = withDedupeTokens(rows, *policy.DedupeColumn); err == nil {
+			valueRange.Values = tokened
+		}
+	}
+	if len(tokens) == 0 {
+		// a timed out append may still have landed, retrying could duplicate rows
+		defer is.wlock(spreadsheetId)()
+		if err = is.retry(false, doAppend); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+	landed := -1
+	err = is.retryGuarded(is.wlock, spreadsheetId, func() (ok bool, err error) {
+		landed, err = is.appendLanded(spreadsheetId, rangeData, *policy.DedupeColumn, tokens)
+		return landed >= 0, err
+	}, doAppend)
+	if err != nil {
+		return nil, err
+	}
+	if ret == nil {
+		// an earlier attempt landed but its reply was lost
+		r, err := ParseRange(rangeData)
+		if err != nil {
+			return nil, err
+		}
+		width := 0
+		for _, row := range valueRange.Values {
+			width = max(width, len(row))
+		}
+		startCol := max(r.StartCol, 0)
+		updated := &Range{Sheet: r.Sheet, StartCol: startCol, StartRow: landed, EndCol: startCol + max(width, 1) - 1, EndRow: landed + len(rows) - 1}
+		ret = &AppendResult{UpdatedRange: updated.String(), FirstRow: landed, LastRow: updated.EndRow, UpdatedRows: len(rows)}
+	}
+	return ret, nil
+}
+
+// appendLanded returns the sheet row where rows carrying tokens, in order,
+// start, -1 when they are not all in the sheet. The caller holds the
+// spreadsheet lock.
+func (is *Gsheet) appendLanded(spreadsheetId, rangeData string, col int, tokens []string) (int, error) {
+	r, err := ParseRange(rangeData)
+	if err != nil {
+		return -1, err
+	}
+	if r.StartCol > 0 {
+		col += r.StartCol
+	}
+	resp, err := is.Spreadsheets.Values.Get(spreadsheetId, ColumnsRange(r.Sheet, col, col).String()).Do()
+	if err != nil {
+		return -1, err
+	}
+	cell := func(i int) string {
+		if i < len(resp.Values) && len(resp.Values[i]) != 0 {
+			return fmt.Sprint(resp.Values[i][0])
+		}
+		return ""
+	}
+	for i := range resp.Values {
+		if cell(i) != tokens[0] {
+			continue
+		}
+		j := 1
+		for j < len(tokens) && cell(i+j) == tokens[j] {
+			j++
+		}
+		if j == len(tokens) {
+			return i, nil
+		}
+	}
+	return -1, nil
 }
 
 func (is *Gsheet) ListSheets(sprids ...string) (map[string]int64, error) {
@@ -318,11 +699,17 @@ func (is *Gsheet) ListSheets(sprids ...string) (map[string]int64, error) {
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	resp, err := is.Spreadsheets.Get(spreadsheetId).Do()
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.Spreadsheet
+	err := is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Get(spreadsheetId).Fields("sheets.properties(title,sheetId)").Do()
+		return err
+	})
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	ret := map[string]int64{}
 	for _, v := range resp.Sheets {
@@ -356,10 +743,8 @@ func (is *Gsheet) CreaateSheet(nameSheet string, sprids ...string) (int64, error
 		IncludeSpreadsheetInResponse: true,
 		Requests:                     []*sheets.Request{&sheets.Request{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: nameSheet}}}},
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	respone, err := is.Spreadsheets.BatchUpdate(spreadsheetId, rq).Do()
-	if err != nil {
+	respone, err := is.batchUpdate(spreadsheetId, rq, false)
+	if err != nil || is.recorder != nil {
 		return 0, err
 	}
 	for _, v := range respone.UpdatedSpreadsheet.Sheets {
@@ -375,13 +760,14 @@ func (is *Gsheet) DeleteSheetId(sheetid int64, sprids ...string) error {
 	if len(sprids) != 0 {
 		spreadsheetId = sprids[0]
 	}
+	if err := is.checkGrid(spreadsheetId, sheetid, NewRange("")); err != nil {
+		return err
+	}
 	rq := &sheets.BatchUpdateSpreadsheetRequest{
 		IncludeSpreadsheetInResponse: false,
 		Requests:                     []*sheets.Request{&sheets.Request{DeleteSheet: &sheets.DeleteSheetRequest{SheetId: sheetid}}},
 	}
-	is.mutex.Lock()
-	defer is.mutex.Unlock()
-	_, err := is.Spreadsheets.BatchUpdate(spreadsheetId, rq).Do()
+	_, err := is.batchUpdate(spreadsheetId, rq, false)
 	return err
 }
 
@@ -398,3 +784,141 @@ func (is *Gsheet) DeleteSheetFromName(sheetid string, sprids ...string) error {
 		return fmt.Errorf("can not find sheetid %s", sheetid)
 	}
 }
+
+// RenameSheetId changes the title of the sheet with id sheetid to newName.
+func (is *Gsheet) RenameSheetId(sheetid int64, newName string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(newName) == 0 {
+		return fmt.Errorf("empty sheet name")
+	}
+	if err := is.checkGrid(spreadsheetId, sheetid, NewRange("")); err != nil {
+		return err
+	}
+	_, err := is.Batch(spreadsheetId).UpdateSheetProperties(&sheets.SheetProperties{SheetId: sheetid, Title: newName}, "title").Do()
+	return err
+}
+
+// RenameSheet changes the title of sheet oldName to newName.
+func (is *Gsheet) RenameSheet(oldName, newName string, sprids ...string) error {
+	sheetid, err := is.GetSheetIdFromNAme(oldName, sprids...)
+	if err != nil {
+		return fmt.Errorf("can not find sheet %s: %w", oldName, err)
+	}
+	return is.RenameSheetId(sheetid, newName, sprids...)
+}
+
+// DuplicateSheet copies the sheet with id sheetid, with its values, formats
+// and validation, into a new sheet named newName at tab position insertIndex,
+// or right after the source when insertIndex < 0. It returns the new sheet
+// id.
+func (is *Gsheet) DuplicateSheet(sheetid int64, newName string, insertIndex int, sprids ...string) (int64, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	req := &sheets.DuplicateSheetRequest{SourceSheetId: sheetid, NewSheetName: newName}
+	if insertIndex >= 0 {
+		req.InsertSheetIndex = int64(insertIndex)
+		req.ForceSendFields = []string{"InsertSheetIndex"}
+	}
+	resp, err := is.Batch(spreadsheetId).Raw(&sheets.Request{DuplicateSheet: req}).Do()
+	if err != nil || is.recorder != nil {
+		return 0, err
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].DuplicateSheet == nil {
+		return 0, fmt.Errorf("can not find sheet after duplicate")
+	}
+	return resp.Replies[0].DuplicateSheet.Properties.SheetId, nil
+}
+
+// CopySheetTo copies the sheet with id sheetid into spreadsheet
+// dstSpreadsheetId and returns the properties of the copy. The copy is
+// titled "Copy of ..." unless newName is given.
+func (is *Gsheet) CopySheetTo(sheetid int64, dstSpreadsheetId, newName string, sprids ...string) (*sheets.SheetProperties, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if is.recorder != nil {
+		if len(newName) != 0 {
+			return nil, fmt.Errorf("renaming the copy needs its id: %w", ErrBuildOnly)
+		}
+		is.recorder.add(Call{Method: "sheets.copyTo", SpreadsheetId: spreadsheetId, SheetId: sheetid, Destination: dstSpreadsheetId})
+		return nil, nil
+	}
+	var props *sheets.SheetProperties
+	err := func() error {
+		defer is.invalidate(dstSpreadsheetId)
+		defer is.wlock(dstSpreadsheetId)()
+		// a retried copy would leave a second sheet behind
+		return is.retry(false, func() (err error) {
+			props, err = is.Spreadsheets.Sheets.CopyTo(spreadsheetId, sheetid, &sheets.CopySheetToAnotherSpreadsheetRequest{DestinationSpreadsheetId: dstSpreadsheetId}).Do()
+			return err
+		})
+	}()
+	if err != nil || len(newName) == 0 {
+		return props, err
+	}
+	if err = is.RenameSheetId(props.SheetId, newName, dstSpreadsheetId); err != nil {
+		return props, err
+	}
+	props.Title = newName
+	return props, nil
+}
+
+// SetSheetIndex moves the sheet with id sheetid to tab position index,
+// zero-based.
+func (is *Gsheet) SetSheetIndex(sheetid int64, index int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if err := is.checkGrid(spreadsheetId, sheetid, NewRange("")); err != nil {
+		return err
+	}
+	_, err := is.Batch(spreadsheetId).Raw(sheetIndexRequest(sheetid, index)).Do()
+	return err
+}
+
+func sheetIndexRequest(sheetid int64, index int) *sheets.Request {
+	return &sheets.Request{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+		Properties: &sheets.SheetProperties{SheetId: sheetid, Index: int64(index), ForceSendFields: []string{"Index"}},
+		Fields:     "index",
+	}}
+}
+
+// ReorderSheets moves the sheets named titles to the first tabs, in that
+// order, in one batch; sheets not listed keep their order after them.
+func (is *Gsheet) ReorderSheets(titles []string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	lsheets, err := is.ListSheets(spreadsheetId)
+	if err != nil {
+		return err
+	}
+	b := is.Batch(spreadsheetId)
+	seen := map[string]bool{}
+	for i, title := range titles {
+		id, ok := lsheets[title]
+		if !ok {
+			return fmt.Errorf("can not find sheet %s", title)
+		}
+		if seen[title] {
+			return fmt.Errorf("sheet %s listed twice", title)
+		}
+		seen[title] = true
+		if err = is.checkGrid(spreadsheetId, id, NewRange("")); err != nil {
+			return err
+		}
+		// placed tabs fill the front, so every move goes towards it and
+		// later moves do not shift earlier ones
+		b.Raw(sheetIndexRequest(id, i))
+	}
+	_, err = b.Do()
+	return err
+}
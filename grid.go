@@ -0,0 +1,64 @@
+package gogsheet
+
+import (
+	"google.golang.org/api/sheets/v4"
+)
+
+// gridFilter selects numRows x numCols cells from the zero-based (row, col)
+// of sheetid; a count <= 0 leaves that side open.
+func gridFilter(sheetid int64, row, col, numRows, numCols int) *sheets.DataFilter {
+	r := &Range{StartCol: col, StartRow: row, EndCol: -1, EndRow: -1}
+	if numRows > 0 {
+		r.EndRow = row + numRows - 1
+	}
+	if numCols > 0 {
+		r.EndCol = col + numCols - 1
+	}
+	return GridFilter(sheetid, r)
+}
+
+// GetGrid reads numRows x numCols cells from the zero-based (row, col) of the
+// sheet with id sheetid, without building A1 strings; a count <= 0 reads to
+// the end of the sheet on that side.
+func (is *Gsheet) GetGrid(sheetid int64, row, col, numRows, numCols int, sprids ...string) ([][]string, error) {
+	matched, err := is.GetValuesByDataFilter([]*sheets.DataFilter{gridFilter(sheetid, row, col, numRows, numCols)}, sprids...)
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) == 0 || len(matched[0].Rows) == 0 {
+		return [][]string{}, is.noData()
+	}
+	return matched[0].Rows, nil
+}
+
+// UpdateGrid writes rows with their top-left cell at the zero-based (row, col)
+// of the sheet with id sheetid.
+func (is *Gsheet) UpdateGrid(rows [][]interface{}, sheetid int64, row, col int, sprids ...string) error {
+	numCols := 0
+	for _, r := range rows {
+		numCols = max(numCols, len(r))
+	}
+	return is.UpdateByDataFilter([]*sheets.DataFilter{gridFilter(sheetid, row, col, len(rows), numCols)}, [][][]interface{}{rows}, sprids...)
+}
+
+// ClearGrid clears the values of numRows x numCols cells from the zero-based
+// (row, col) of the sheet with id sheetid, keeping formats.
+func (is *Gsheet) ClearGrid(sheetid int64, row, col, numRows, numCols int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	filters := []*sheets.DataFilter{gridFilter(sheetid, row, col, numRows, numCols)}
+	if err := is.checkFilters(spreadsheetId, filters); err != nil {
+		return err
+	}
+	if is.recorder != nil {
+		is.recorder.add(Call{Method: "values.batchClearByDataFilter", SpreadsheetId: spreadsheetId, DataFilters: filters})
+		return nil
+	}
+	defer is.invalidate(spreadsheetId)
+	return is.retryLocked(is.wlock, spreadsheetId, func() error {
+		_, err := is.Spreadsheets.Values.BatchClearByDataFilter(spreadsheetId, &sheets.BatchClearValuesByDataFilterRequest{DataFilters: filters}).Do()
+		return err
+	})
+}
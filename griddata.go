@@ -0,0 +1,265 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// getGrid fetches the grid data of readRange limited to cellFields, a fields
+// mask relative to a cell such as "note" or "userEnteredFormat".
+func (is *Gsheet) getGrid(spreadsheetId, readRange, cellFields string) (grid *sheets.GridData, err error) {
+	if err = is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.Spreadsheet
+	err = is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Get(spreadsheetId).Ranges(readRange).IncludeGridData(true).
+			Fields(googleapi.Field(fmt.Sprintf("sheets.data(startRow,startColumn,rowData.values(%s))", cellFields))).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Sheets) == 0 || len(resp.Sheets[0].Data) == 0 {
+		return &sheets.GridData{}, nil
+	}
+	return resp.Sheets[0].Data[0], nil
+}
+
+// gridCells calls fn for every cell returned in grid, with its row and column
+// relative to the start of the range, and returns the size of the grid.
+func gridCells(grid *sheets.GridData, fn func(r, c int, cell *sheets.CellData)) (rows, cols int) {
+	for r, row := range grid.RowData {
+		for c, cell := range row.Values {
+			fn(r, c, cell)
+			cols = max(cols, c+1)
+		}
+	}
+	return len(grid.RowData), cols
+}
+
+// GetNotes returns the note attached to every cell of rangeA1 in sheetName,
+// "" for cells without note.
+func (is *Gsheet) GetNotes(sheetName, rangeA1 string, sprids ...string) ([][]string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	grid, err := is.getGrid(spreadsheetId, QuoteSheetName(sheetName)+"!"+rangeA1, "note")
+	if err != nil {
+		return nil, err
+	}
+	notes := make([][]string, len(grid.RowData))
+	gridCells(grid, func(r, c int, cell *sheets.CellData) {
+		notes[r] = append(notes[r], cell.Note)
+	})
+	return notes, nil
+}
+
+// CellFormat is the user entered format of a cell. Nil fields are unset.
+type CellFormat struct {
+	BackgroundColor     *sheets.Color
+	TextFormat          *sheets.TextFormat
+	NumberFormat        *sheets.NumberFormat
+	HorizontalAlignment string // LEFT, CENTER, RIGHT or ""
+}
+
+// GetCellFormats returns the format of every cell of rangeA1, e.g.
+// "Data!A1:D10", as entered by the user (not inherited from conditional
+// formatting), to copy styling between sheets.
+func (is *Gsheet) GetCellFormats(rangeA1 string, sprids ...string) ([][]CellFormat, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	grid, err := is.getGrid(spreadsheetId, rangeA1, "userEnteredFormat(backgroundColor,textFormat,numberFormat,horizontalAlignment)")
+	if err != nil {
+		return nil, err
+	}
+	formats := make([][]CellFormat, len(grid.RowData))
+	gridCells(grid, func(r, c int, cell *sheets.CellData) {
+		f := CellFormat{}
+		if uf := cell.UserEnteredFormat; uf != nil {
+			f = CellFormat{uf.BackgroundColor, uf.TextFormat, uf.NumberFormat, uf.HorizontalAlignment}
+		}
+		formats[r] = append(formats[r], f)
+	})
+	return formats, nil
+}
+
+// GetHyperlinks returns the link target of every cell of rangeA1, "" for
+// cells without link. A cell linked as a whole or by a HYPERLINK formula
+// gives that URL; otherwise the first link found in its rich text is used.
+func (is *Gsheet) GetHyperlinks(rangeA1 string, sprids ...string) ([][]string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	grid, err := is.getGrid(spreadsheetId, rangeA1, "hyperlink,textFormatRuns.format.link.uri,userEnteredFormat.textFormat.link.uri")
+	if err != nil {
+		return nil, err
+	}
+	links := make([][]string, len(grid.RowData))
+	gridCells(grid, func(r, c int, cell *sheets.CellData) {
+		links[r] = append(links[r], cellLinkURI(cell))
+	})
+	return links, nil
+}
+
+func cellLinkURI(cell *sheets.CellData) string {
+	if len(cell.Hyperlink) != 0 {
+		return cell.Hyperlink
+	}
+	if f := cell.UserEnteredFormat; f != nil && f.TextFormat != nil && f.TextFormat.Link != nil {
+		return f.TextFormat.Link.Uri
+	}
+	for _, run := range cell.TextFormatRuns {
+		if run.Format != nil && run.Format.Link != nil {
+			return run.Format.Link.Uri
+		}
+	}
+	return ""
+}
+
+// DataValidation describes the validation rule of a cell.
+type DataValidation struct {
+	Type         string   // condition type: ONE_OF_LIST, ONE_OF_RANGE, BOOLEAN, CUSTOM_FORMULA, NUMBER_BETWEEN, ...
+	Values       []string // list items, source range, checkbox values or formula
+	Strict       bool     // invalid input is rejected rather than flagged
+	ShowDropdown bool     // lists show a dropdown
+	InputMessage string
+}
+
+// dataValidationOf converts an API rule, nil when rule is empty.
+func dataValidationOf(rule *sheets.DataValidationRule) *DataValidation {
+	if rule == nil || rule.Condition == nil {
+		return nil
+	}
+	dv := &DataValidation{Type: rule.Condition.Type, Strict: rule.Strict, ShowDropdown: rule.ShowCustomUi, InputMessage: rule.InputMessage}
+	for _, v := range rule.Condition.Values {
+		dv.Values = append(dv.Values, v.UserEnteredValue)
+	}
+	return dv
+}
+
+// rule converts dv back to an API rule; a nil dv clears validation.
+func (dv *DataValidation) rule() *sheets.DataValidationRule {
+	if dv == nil {
+		return nil
+	}
+	cond := &sheets.BooleanCondition{Type: dv.Type}
+	for _, v := range dv.Values {
+		cond.Values = append(cond.Values, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+	return &sheets.DataValidationRule{Condition: cond, Strict: dv.Strict, ShowCustomUi: dv.ShowDropdown, InputMessage: dv.InputMessage}
+}
+
+// Equal reports whether dv and other describe the same rule.
+func (dv *DataValidation) Equal(other *DataValidation) bool {
+	if dv == nil || other == nil {
+		return dv == other
+	}
+	return dv.Type == other.Type && slices.Equal(dv.Values, other.Values) && dv.Strict == other.Strict &&
+		dv.ShowDropdown == other.ShowDropdown && dv.InputMessage == other.InputMessage
+}
+
+// GetDataValidation returns the validation rule of every cell of rangeA1,
+// nil for cells without rule, to check that a form-style sheet is set up.
+func (is *Gsheet) GetDataValidation(rangeA1 string, sprids ...string) ([][]*DataValidation, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	grid, err := is.getGrid(spreadsheetId, rangeA1, "dataValidation")
+	if err != nil {
+		return nil, err
+	}
+	rules := make([][]*DataValidation, len(grid.RowData))
+	gridCells(grid, func(r, c int, cell *sheets.CellData) {
+		rules[r] = append(rules[r], dataValidationOf(cell.DataValidation))
+	})
+	return rules, nil
+}
+
+// SetNote attaches text as the note of cellAddress of sheetName, e.g. "B2",
+// replacing any previous note; an empty text removes it.
+func (is *Gsheet) SetNote(sheetName, cellAddress, text string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, fmt.Sprintf("%s!%s", sheetName, cellAddress))
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r, &sheets.CellData{Note: text}, "note").Do()
+	return err
+}
+
+// ClearNotes removes the notes of every cell of rangeA1, keeping values.
+func (is *Gsheet) ClearNotes(rangeA1 string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r, &sheets.CellData{}, "note").Do()
+	return err
+}
+
+// SetHyperlink makes cellAddress of sheetName, e.g. "B2", show label linked
+// to url as rich text, which keeps the cell a plain value; an empty label
+// shows the url. When the API rejects the link, a HYPERLINK formula is
+// written instead.
+func (is *Gsheet) SetHyperlink(sheetName, cellAddress, url, label string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(label) == 0 {
+		label = url
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, fmt.Sprintf("%s!%s", sheetName, cellAddress))
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r, &sheets.CellData{
+		UserEnteredValue:  &sheets.ExtendedValue{StringValue: &label},
+		UserEnteredFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Link: &sheets.Link{Uri: url}}},
+	}, "userEnteredValue,userEnteredFormat.textFormat.link").Do()
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusBadRequest {
+		return is.SetFormula(sheetName, cellAddress, fmt.Sprintf("=HYPERLINK(%s,%s)", FormulaString(url), FormulaString(label)), spreadsheetId)
+	}
+	return err
+}
+
+// SetCellImage shows the image at imageURL inside cellAddress of sheetName,
+// e.g. "B2", scaled to fit. The API cannot write in-cell image values, so an
+// IMAGE formula is used; altText, which the formula cannot carry, becomes
+// the note of the cell. The URL must be publicly reachable.
+func (is *Gsheet) SetCellImage(sheetName, cellAddress, imageURL, altText string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, fmt.Sprintf("%s!%s", sheetName, cellAddress))
+	if err != nil {
+		return err
+	}
+	formula := fmt.Sprintf("=IMAGE(%s)", FormulaString(imageURL))
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r, &sheets.CellData{
+		UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula},
+		Note:             altText,
+	}, "userEnteredValue,note").Do()
+	return err
+}
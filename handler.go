@@ -0,0 +1,172 @@
+package gogsheet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnauthorized can be returned by HandlerOptions.Auth to answer 401
+// instead of 403.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// HandlerOptions configures a sheet exposed over HTTP by Handler.
+type HandlerOptions struct {
+	Range         string // range served by GET and appended to by POST
+	SpreadsheetId string // default spreadsheet of the client when empty
+	ReadOnly      bool   // answer POST with 405
+	// Auth, when set, is called for every request; a non nil error rejects it.
+	Auth func(r *http.Request) error
+	// RateLimit limits requests per second over all clients, 0 is unlimited.
+	RateLimit float64
+	Burst     int
+}
+
+// Handler returns an http.Handler exposing opts.Range as a REST resource:
+//
+//	GET  ?format=json (default) -> [["a","b"],...]
+//	GET  ?format=records        -> [{"header":"value",...},...] using the first row as header
+//	GET  ?format=csv            -> text/csv
+//	POST JSON [[...],...] or text/csv body -> rows appended below the range
+func (is *Gsheet) Handler(opts HandlerOptions) http.Handler {
+	spreadsheetId := opts.SpreadsheetId
+	if len(spreadsheetId) == 0 {
+		spreadsheetId = is.spreadsheetId
+	}
+	var limiter *tokenBucket
+	if opts.RateLimit > 0 {
+		limiter = newTokenBucket(opts.RateLimit, max(opts.Burst, 1))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts.Auth != nil {
+			if err := opts.Auth(r); err != nil {
+				code := http.StatusForbidden
+				if errors.Is(err, ErrUnauthorized) {
+					code = http.StatusUnauthorized
+				}
+				http.Error(w, err.Error(), code)
+				return
+			}
+		}
+		if limiter != nil && !limiter.allow() {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			is.serveRange(w, r, opts.Range, spreadsheetId)
+		case http.MethodPost:
+			if opts.ReadOnly {
+				w.Header().Set("Allow", "GET, HEAD")
+				http.Error(w, "read-only resource", http.StatusMethodNotAllowed)
+				return
+			}
+			is.serveAppend(w, r, opts.Range, spreadsheetId)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (is *Gsheet) serveRange(w http.ResponseWriter, r *http.Request, readRange, spreadsheetId string) {
+	resp, err := is.getValues(spreadsheetId, readRange, is.readOptions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	rows := TrimRows(stringRows(resp.Values), is.trim)
+	if len(rows) != 0 {
+		sheet, header, skip, err := is.maskHeader(spreadsheetId, readRange, rows[0])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		if err = is.maskStrings(sheet, header, rows[skip:], false); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		cw := csv.NewWriter(w)
+		cw.WriteAll(rows)
+	case "records":
+		writeJSON(w, rowsToRecords(rows))
+	default:
+		writeJSON(w, rows)
+	}
+}
+
+func (is *Gsheet) serveAppend(w http.ResponseWriter, r *http.Request, rangeData, spreadsheetId string) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 32<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rows := [][]interface{}{}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		records, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rec := range records {
+			row := []interface{}{}
+			for _, s := range rec {
+				row = append(row, s)
+			}
+			rows = append(rows, row)
+		}
+	} else if err = json.Unmarshal(body, &rows); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err = is.AppendRows(rows, rangeData, spreadsheetId); err != nil {
+		code := http.StatusBadGateway
+		if errors.Is(err, ErrPolicy) {
+			code = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// tokenBucket is a minimal thread safe rate limiter.
+type tokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
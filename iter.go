@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package gogsheet
+
+import "iter"
+
+// All returns the remaining rows as a range-over-func sequence of
+// (zero-based sheet row index, row). Check Err after the loop.
+func (it *RowIterator) All() iter.Seq2[int, []string] {
+	return func(yield func(int, []string) bool) {
+		for it.Next() {
+			if !yield(it.Index(), it.Row()) {
+				return
+			}
+		}
+	}
+}
+
+// Rows iterates readRange lazily, fetching DefaultChunkRows rows per call:
+//
+//	for i, row := range g.Rows("Data!A2:F") { ... }
+//
+// A failed fetch ends the sequence early; use NewRowIterator and All when the
+// error matters.
+func (is *Gsheet) Rows(readRange string, sprids ...string) iter.Seq2[int, []string] {
+	return func(yield func(int, []string) bool) {
+		it, err := is.NewRowIterator(readRange, DefaultChunkRows, sprids...)
+		if err != nil {
+			return
+		}
+		it.All()(yield)
+	}
+}
+
+// Sheets iterates (title, sheet id) of the spreadsheet's sheets in tab order.
+// A failed fetch yields nothing.
+func (is *Gsheet) Sheets(sprids ...string) iter.Seq2[string, int64] {
+	return func(yield func(string, int64) bool) {
+		spreadsheetId := is.spreadsheetId
+		if len(sprids) != 0 {
+			spreadsheetId = sprids[0]
+		}
+		info, err := is.describeSpreadsheet(spreadsheetId)
+		if err != nil {
+			return
+		}
+		for _, sh := range info.Sheets {
+			if !yield(sh.Title, sh.SheetId) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultChunkRows is the window size of chunked reads when none is given.
+var DefaultChunkRows = 5000
+
+// RowIterator reads a range lazily in windows of rows, so huge sheets never
+// have to fit in memory or in one API response.
+//
+//	it, err := g.NewRowIterator("Data!A2:F", 5000)
+//	for it.Next() {
+//		use(it.Index(), it.Row())
+//	}
+//	if it.Err() != nil { ... }
+type RowIterator struct {
+	is            *Gsheet
+	spreadsheetId string
+	rng           Range
+	chunk         int
+	tuneKey       string // set when the chunk size is tuned adaptively
+	next          int    // first row of the next window
+	last          int    // last row to read, inclusive
+	buf           [][]string
+	pos           int
+	row           []string
+	index         int
+	err           error
+}
+
+// NewRowIterator prepares a chunked read of readRange, chunkRows rows per API
+// call. When chunkRows <= 0 the size is tuned per window if adaptive chunking
+// is on, DefaultChunkRows otherwise. Open ranges are bounded by the sheet's
+// grid size, fetched once up front.
+func (is *Gsheet) NewRowIterator(readRange string, chunkRows int, sprids ...string) (*RowIterator, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	tuned := chunkRows <= 0 && is.tuner != nil
+	if chunkRows <= 0 {
+		chunkRows = DefaultChunkRows
+	}
+	r, err := ParseRange(readRange)
+	if err != nil {
+		return nil, err
+	}
+	rowCount, err := is.sheetRowCount(spreadsheetId, r.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	it := &RowIterator{is: is, spreadsheetId: spreadsheetId, rng: *r, chunk: chunkRows, next: max(r.StartRow, 0), last: int(rowCount) - 1}
+	if r.EndRow >= 0 && r.EndRow < it.last {
+		it.last = r.EndRow
+	}
+	if tuned {
+		it.tuneKey = spreadsheetId + "!" + r.Sheet
+	}
+	return it, nil
+}
+
+// sheetRowCount returns the grid row count of sheetName, the first sheet when empty.
+func (is *Gsheet) sheetRowCount(spreadsheetId, sheetName string) (int64, error) {
+	info, err := is.describeSpreadsheet(spreadsheetId)
+	if err != nil {
+		return 0, err
+	}
+	for _, sh := range info.Sheets {
+		if len(sheetName) == 0 || strings.EqualFold(sh.Title, sheetName) {
+			return sh.RowCount, nil
+		}
+	}
+	return 0, fmt.Errorf("can not find sheet %s", sheetName)
+}
+
+func (it *RowIterator) fetch() bool {
+	for it.pos >= len(it.buf) {
+		if it.next > it.last {
+			return false
+		}
+		tuner := it.is.tuner
+		if len(it.tuneKey) != 0 && tuner != nil {
+			it.chunk, _ = tuner.plan(it.tuneKey)
+		}
+		window := it.rng
+		window.StartRow, window.EndRow = it.next, min(it.next+it.chunk-1, it.last)
+		start := time.Now()
+		resp, err := it.is.getValues(it.spreadsheetId, window.String(), it.is.readOptions)
+		if len(it.tuneKey) != 0 && tuner != nil {
+			tuner.observe(it.tuneKey, window.EndRow-window.StartRow+1, time.Since(start), err)
+		}
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.index = it.next - 1
+		it.buf, it.pos = stringRows(resp.Values), 0
+		it.next = window.EndRow + 1
+	}
+	return true
+}
+
+// Next advances to the next row, fetching the next window when needed.
+func (it *RowIterator) Next() bool {
+	if it.err != nil || !it.fetch() {
+		return false
+	}
+	it.row = it.buf[it.pos]
+	it.pos++
+	it.index++
+	return true
+}
+
+// Row returns the current row.
+func (it *RowIterator) Row() []string {
+	return it.row
+}
+
+// Index returns the zero-based sheet row index of the current row.
+func (it *RowIterator) Index() int {
+	return it.index
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// StreamRange reads readRange in chunks of DefaultChunkRows rows, or tuned
+// chunks when adaptive chunking is on, and calls fn for every row with its
+// zero-based sheet row index. Memory stays constant; the first error returned
+// by fn stops the stream and is returned.
+func (is *Gsheet) StreamRange(readRange string, fn func(rowIndex int, row []string) error, sprids ...string) error {
+	it, err := is.NewRowIterator(readRange, 0, sprids...)
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		if err = fn(it.Index(), it.Row()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// DefaultReadWorkers bounds GetValueRangeParallel when no worker count is given.
+var DefaultReadWorkers = 4
+
+// GetValueRangeParallel reads readRange as windows of rows fetched
+// concurrently by at most workers calls at a time (DefaultReadWorkers, or the
+// tuned parallelism when adaptive chunking is on, for workers <= 0) and
+// reassembles them in order. The result is trimmed like GetValueRange.
+func (is *Gsheet) GetValueRangeParallel(readRange string, workers int, sprids ...string) ([][]string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	r, err := ParseRange(readRange)
+	if err != nil {
+		return nil, err
+	}
+	rowCount, err := is.sheetRowCount(spreadsheetId, r.Sheet)
+	if err != nil {
+		return nil, err
+	}
+	first, last := max(r.StartRow, 0), int(rowCount)-1
+	if r.EndRow >= 0 && r.EndRow < last {
+		last = r.EndRow
+	}
+	chunk, key := DefaultChunkRows, spreadsheetId+"!"+r.Sheet
+	tuner := is.tuner
+	if tuner != nil {
+		var parallel int
+		chunk, parallel = tuner.plan(key)
+		if workers <= 0 {
+			workers = parallel
+		}
+	}
+	if workers <= 0 {
+		workers = DefaultReadWorkers
+	}
+	windows := []Range{}
+	for start := first; start <= last; start += chunk {
+		w := *r
+		w.StartRow, w.EndRow = start, min(start+chunk-1, last)
+		windows = append(windows, w)
+	}
+	parts := make([][][]string, len(windows))
+	errs := make([]error, len(windows))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+	for i := range windows {
+		if failed.Load() {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			w := windows[i]
+			start := time.Now()
+			resp, err := is.getValues(spreadsheetId, w.String(), is.readOptions)
+			if tuner != nil {
+				tuner.observe(key, w.EndRow-w.StartRow+1, time.Since(start), err)
+			}
+			if err != nil {
+				errs[i] = err
+				failed.Store(true)
+				return
+			}
+			parts[i] = stringRows(resp.Values)
+		}(i)
+	}
+	wg.Wait()
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	ret := [][]string{}
+	for i, part := range parts {
+		ret = append(ret, part...)
+		// windows drop their trailing empty rows, keep the rows aligned
+		if i < len(parts)-1 {
+			for n := windows[i].EndRow - windows[i].StartRow + 1 - len(part); n > 0; n-- {
+				ret = append(ret, []string{})
+			}
+		}
+	}
+	if ret = TrimRows(ret, is.trim); len(ret) == 0 {
+		return [][]string{}, is.noData()
+	}
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+package gogsheet
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocaleFormatter renders Go values as a spreadsheet locale displays them,
+// for writes that send text as is (RAW input) yet should read naturally.
+type LocaleFormatter struct {
+	Locale     string
+	Location   *time.Location // times are converted to it, UTC when nil
+	Decimal    string         // decimal separator
+	DateLayout string         // time.Format layout of dates
+}
+
+var dotDateLanguages = map[string]bool{
+	"de": true, "ru": true, "pl": true, "cs": true, "sk": true, "fi": true, "nb": true, "da": true,
+	"tr": true, "uk": true, "ro": true, "bg": true, "hr": true, "sl": true,
+}
+
+var isoDateLanguages = map[string]bool{"ja": true, "zh": true, "ko": true, "hu": true, "lt": true, "sv": true}
+
+// NewLocaleFormatter returns the formatter of locale (e.g. "de_DE") in the
+// IANA timeZone, UTC when empty or unknown.
+func NewLocaleFormatter(locale, timeZone string) *LocaleFormatter {
+	lang := strings.ToLower(strings.SplitN(strings.SplitN(locale, "_", 2)[0], "-", 2)[0])
+	f := &LocaleFormatter{Locale: locale, Location: time.UTC, Decimal: ".", DateLayout: "02/01/2006"}
+	if loc, err := time.LoadLocation(timeZone); err == nil && len(timeZone) != 0 {
+		f.Location = loc
+	}
+	if decimalCommaLanguages[lang] {
+		f.Decimal = ","
+	}
+	switch {
+	case locale == "en_US" || locale == "en" || len(locale) == 0:
+		f.DateLayout = "1/2/2006"
+	case isoDateLanguages[lang]:
+		f.DateLayout = "2006-01-02"
+	case dotDateLanguages[lang]:
+		f.DateLayout = "02.01.2006"
+	case lang == "nl":
+		f.DateLayout = "02-01-2006"
+	}
+	return f
+}
+
+// LocaleFormatter returns the formatter of the spreadsheet's locale and time zone.
+func (is *Gsheet) LocaleFormatter(sprids ...string) (*LocaleFormatter, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	info, err := is.spreadsheetLocale(spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocaleFormatter(info.Locale, info.TimeZone), nil
+}
+
+// Format returns numbers and times as locale formatted strings; other values
+// are returned unchanged. Times with a clock part get " 15:04:05" appended.
+func (f *LocaleFormatter) Format(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		return f.float(v)
+	case float32:
+		return f.float(float64(v))
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		if f.Location != nil {
+			v = v.In(f.Location)
+		}
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			return v.Format(f.DateLayout)
+		}
+		return v.Format(f.DateLayout + " 15:04:05")
+	}
+	return v
+}
+
+func (f *LocaleFormatter) float(v float64) string {
+	return strings.Replace(strconv.FormatFloat(v, 'f', -1, 64), ".", f.Decimal, 1)
+}
+
+// FormatRows returns a copy of rows with every value passed through Format.
+func (f *LocaleFormatter) FormatRows(rows [][]interface{}) [][]interface{} {
+	ret := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		ret[i] = make([]interface{}, len(row))
+		for j, v := range row {
+			ret[i][j] = f.Format(v)
+		}
+	}
+	return ret
+}
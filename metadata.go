@@ -0,0 +1,286 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// DefaultDescribeParallel bounds DescribeSpreadsheets when no limit is given.
+var DefaultDescribeParallel = 4
+
+const describeFields = "spreadsheetId,properties(title,locale,timeZone)," +
+	"sheets.properties(sheetId,title,index,sheetType,hidden,rightToLeft," +
+	"gridProperties(rowCount,columnCount,frozenRowCount,frozenColumnCount,hideGridlines))"
+
+type SheetInfo struct {
+	SheetId           int64
+	Title             string
+	Index             int64
+	SheetType         string // GRID, OBJECT or DATA_SOURCE
+	Hidden            bool
+	RightToLeft       bool
+	RowCount          int64
+	ColumnCount       int64
+	FrozenRowCount    int64
+	FrozenColumnCount int64
+	HideGridlines     bool
+}
+
+type SpreadsheetInfo struct {
+	SpreadsheetId string
+	Title         string
+	Locale        string
+	TimeZone      string
+	Sheets        []SheetInfo
+}
+
+func newSpreadsheetInfo(resp *sheets.Spreadsheet) *SpreadsheetInfo {
+	info := &SpreadsheetInfo{SpreadsheetId: resp.SpreadsheetId}
+	if resp.Properties != nil {
+		info.Title = resp.Properties.Title
+		info.Locale = resp.Properties.Locale
+		info.TimeZone = resp.Properties.TimeZone
+	}
+	for _, v := range resp.Sheets {
+		if v.Properties == nil {
+			continue
+		}
+		sheet := SheetInfo{
+			SheetId:     v.Properties.SheetId,
+			Title:       v.Properties.Title,
+			Index:       v.Properties.Index,
+			SheetType:   v.Properties.SheetType,
+			Hidden:      v.Properties.Hidden,
+			RightToLeft: v.Properties.RightToLeft,
+		}
+		if gp := v.Properties.GridProperties; gp != nil {
+			sheet.RowCount = gp.RowCount
+			sheet.ColumnCount = gp.ColumnCount
+			sheet.FrozenRowCount = gp.FrozenRowCount
+			sheet.FrozenColumnCount = gp.FrozenColumnCount
+			sheet.HideGridlines = gp.HideGridlines
+		}
+		info.Sheets = append(info.Sheets, sheet)
+	}
+	return info
+}
+
+// GetMetadata fetches the default spreadsheet limited to the fields masks,
+// e.g. GetMetadata("sheets.properties(title,sheetId)"); no mask returns
+// everything, including grid metadata that can be large.
+func (is *Gsheet) GetMetadata(fields ...string) (*sheets.Spreadsheet, error) {
+	return is.GetMetadataOf(is.spreadsheetId, fields...)
+}
+
+// GetMetadataOf is GetMetadata for another spreadsheet.
+func (is *Gsheet) GetMetadataOf(spreadsheetId string, fields ...string) (resp *sheets.Spreadsheet, err error) {
+	if err = is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	err = is.retry(true, func() (err error) {
+		call := is.Spreadsheets.Get(spreadsheetId)
+		if len(fields) != 0 {
+			call.Fields(googleapi.Field(strings.Join(fields, ",")))
+		}
+		resp, err = call.Do()
+		return err
+	})
+	return resp, err
+}
+
+// GetSpreadsheetInfo fetches the title, locale and time zone of the
+// spreadsheet with the properties of every sheet, in tab order.
+func (is *Gsheet) GetSpreadsheetInfo(sprids ...string) (*SpreadsheetInfo, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.describeSpreadsheet(spreadsheetId)
+}
+
+func (is *Gsheet) describeSpreadsheet(spreadsheetId string) (*SpreadsheetInfo, error) {
+	resp, err := is.GetMetadataOf(spreadsheetId, describeFields)
+	if err != nil {
+		return nil, err
+	}
+	return newSpreadsheetInfo(resp), nil
+}
+
+// sheetInfo returns the properties of sheetName, the first sheet when empty.
+func (is *Gsheet) sheetInfo(spreadsheetId, sheetName string) (*SheetInfo, error) {
+	info, err := is.describeSpreadsheet(spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	for i, sh := range info.Sheets {
+		if len(sheetName) == 0 || strings.EqualFold(sh.Title, sheetName) {
+			return &info.Sheets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("can not find sheet %s", sheetName)
+}
+
+// DescribeSpreadsheets fetches title, locale, time zone and sheet sizes of many
+// spreadsheets concurrently, at most maxParallel (default DefaultDescribeParallel)
+// at a time. Spreadsheets that fail are left out of the map and reported in the
+// returned error.
+func (is *Gsheet) DescribeSpreadsheets(spreadsheetIds []string, maxParallel ...int) (map[string]*SpreadsheetInfo, error) {
+	parallel := DefaultDescribeParallel
+	if len(maxParallel) != 0 && maxParallel[0] > 0 {
+		parallel = maxParallel[0]
+	}
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		errs  []error
+		sem   = make(chan struct{}, parallel)
+		infos = map[string]*SpreadsheetInfo{}
+	)
+	for _, id := range spreadsheetIds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			info, err := is.describeSpreadsheet(id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", id, err))
+				return
+			}
+			infos[id] = info
+		}(id)
+	}
+	wg.Wait()
+	return infos, errors.Join(errs...)
+}
+
+// GetMerges returns the merged regions of sheetName, e.g. a header cell
+// spanning A1:C1; String() renders each as an A1 range.
+func (is *Gsheet) GetMerges(sheetName string, sprids ...string) ([]*Range, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	resp, err := is.GetMetadataOf(spreadsheetId, "sheets(properties.title,merges)")
+	if err != nil {
+		return nil, err
+	}
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties.Title != sheetName {
+			continue
+		}
+		merges := make([]*Range, 0, len(sheet.Merges))
+		for _, gr := range sheet.Merges {
+			merges = append(merges, gridToRange(sheetName, gr))
+		}
+		return merges, nil
+	}
+	return nil, fmt.Errorf("sheet %s not found", sheetName)
+}
+
+// GetUsedRange returns the bounding rectangle of the non-empty cells of
+// sheetName, nil when the sheet is empty. It does not read the whole sheet:
+// windows of rows, then of columns, growing from each edge of the grid are
+// read until one holds a value, so only the first and last rows and columns
+// of the data are fetched, each column over its full height.
+func (is *Gsheet) GetUsedRange(sheetName string, sprids ...string) (*Range, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheet, err := is.sheetInfo(spreadsheetId, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	used := &Range{Sheet: sheetName}
+	if used.EndRow, err = is.usedEdge(spreadsheetId, sheetName, DimensionRows, int(sheet.RowCount), true); err != nil || used.EndRow < 0 {
+		return nil, err
+	}
+	if used.StartRow, err = is.usedEdge(spreadsheetId, sheetName, DimensionRows, used.EndRow+1, false); err != nil {
+		return nil, err
+	}
+	if used.EndCol, err = is.usedEdge(spreadsheetId, sheetName, DimensionColumns, int(sheet.ColumnCount), true); err != nil {
+		return nil, err
+	}
+	if used.StartCol, err = is.usedEdge(spreadsheetId, sheetName, DimensionColumns, used.EndCol+1, false); err != nil {
+		return nil, err
+	}
+	return used, nil
+}
+
+// usedEdge returns the index of the first row or column, dim, of the first
+// count ones of sheetName holding a non-empty value, the last one with
+// fromEnd, -1 when there is none. Windows starting at the edge and doubling
+// in size are read one after the other, so the values read stop at the
+// window where the data starts.
+func (is *Gsheet) usedEdge(spreadsheetId, sheetName, dim string, count int, fromEnd bool) (int, error) {
+	opts := ReadOptions{ValueRenderOption: UnformattedValue, MajorDimension: dim}
+	size := 64
+	if dim == DimensionColumns {
+		size = 1
+	}
+	for done := 0; done < count; size *= 2 {
+		lo, hi := done, min(done+size, count)
+		if fromEnd {
+			lo, hi = count-hi, count-done
+		}
+		r := RowsRange(sheetName, lo, hi-1)
+		if dim == DimensionColumns {
+			r = ColumnsRange(sheetName, lo, hi-1)
+		}
+		resp, err := is.getValues(spreadsheetId, r.String(), opts)
+		if err != nil {
+			return -1, err
+		}
+		for i := range resp.Values {
+			if fromEnd {
+				i = len(resp.Values) - 1 - i
+			}
+			for _, v := range resp.Values[i] {
+				if v != nil && v != "" {
+					return lo + i, nil
+				}
+			}
+		}
+		done += hi - lo
+	}
+	return -1, nil
+}
+
+// GetLastRow returns the zero-based index of the last non-empty row of
+// sheetName, -1 when the sheet is empty. Like GetUsedRange it only reads
+// the rows near the end of the data.
+func (is *Gsheet) GetLastRow(sheetName string, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheet, err := is.sheetInfo(spreadsheetId, sheetName)
+	if err != nil {
+		return -1, err
+	}
+	return is.usedEdge(spreadsheetId, sheetName, DimensionRows, int(sheet.RowCount), true)
+}
+
+// GetLastColumn returns the zero-based index of the last non-empty column of
+// sheetName, -1 when the sheet is empty. Like GetUsedRange it only reads
+// the columns near the end of the data.
+func (is *Gsheet) GetLastColumn(sheetName string, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheet, err := is.sheetInfo(spreadsheetId, sheetName)
+	if err != nil {
+		return -1, err
+	}
+	return is.usedEdge(spreadsheetId, sheetName, DimensionColumns, int(sheet.ColumnCount), true)
+}
@@ -0,0 +1,109 @@
+package gogsheet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// fakeGrid serves the metadata and values.get calls of one sheet holding
+// cells, a rows x cols grid, and counts the cells it returns.
+func fakeGrid(t *testing.T, rows, cols int, cells map[[2]int]interface{}) (*Gsheet, *int) {
+	served := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := strings.Index(r.URL.Path, "/values/")
+		if i < 0 {
+			json.NewEncoder(w).Encode(&sheets.Spreadsheet{Sheets: []*sheets.Sheet{{Properties: &sheets.SheetProperties{
+				Title:          "Data",
+				GridProperties: &sheets.GridProperties{RowCount: int64(rows), ColumnCount: int64(cols)},
+			}}}})
+			return
+		}
+		rg, err := ParseRange(r.URL.Path[i+len("/values/"):])
+		if err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r0, r1, c0, c1 := max(rg.StartRow, 0), rg.EndRow, max(rg.StartCol, 0), rg.EndCol
+		if r1 < 0 {
+			r1 = rows - 1
+		}
+		if c1 < 0 {
+			c1 = cols - 1
+		}
+		columns := r.URL.Query().Get("majorDimension") == DimensionColumns
+		values := [][]interface{}{}
+		outer, inner := [2]int{r0, r1}, [2]int{c0, c1}
+		if columns {
+			outer, inner = inner, outer
+		}
+		for a := outer[0]; a <= outer[1]; a++ {
+			line := []interface{}{}
+			for b := inner[0]; b <= inner[1]; b++ {
+				key := [2]int{a, b}
+				if columns {
+					key = [2]int{b, a}
+				}
+				if v, ok := cells[key]; ok {
+					for len(line) < b-inner[0] {
+						line = append(line, "")
+					}
+					line = append(line, v)
+					served++
+				}
+			}
+			values = append(values, line)
+		}
+		for len(values) != 0 && len(values[len(values)-1]) == 0 {
+			values = values[:len(values)-1]
+		}
+		json.NewEncoder(w).Encode(&sheets.ValueRange{Values: values})
+	}))
+	t.Cleanup(srv.Close)
+	service, err := sheets.NewService(context.Background(), option.WithEndpoint(srv.URL), option.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Gsheet{Service: service, spreadsheetId: "id", locks: map[string]*sync.RWMutex{}}, &served
+}
+
+func TestGetUsedRangeProbesEdges(t *testing.T) {
+	cells := map[[2]int]interface{}{}
+	for r := 3; r < 2000; r++ {
+		for c := 2; c < 20; c++ {
+			cells[[2]int{r, c}] = r
+		}
+	}
+	cells[[2]int{2500, 4}] = "last"
+	is, served := fakeGrid(t, 10000, 26, cells)
+	used, err := is.GetUsedRange("Data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Range{Sheet: "Data", StartCol: 2, StartRow: 3, EndCol: 19, EndRow: 2500}
+	if used == nil || *used != want {
+		t.Fatalf("got %+v, want %+v", used, want)
+	}
+	if *served >= len(cells)/2 {
+		t.Errorf("read %d cells of %d", *served, len(cells))
+	}
+	if last, err := is.GetLastRow("Data"); err != nil || last != 2500 {
+		t.Errorf("last row %d, %v", last, err)
+	}
+	if last, err := is.GetLastColumn("Data"); err != nil || last != 19 {
+		t.Errorf("last column %d, %v", last, err)
+	}
+
+	is, _ = fakeGrid(t, 1000, 26, nil)
+	if used, err = is.GetUsedRange("Data"); err != nil || used != nil {
+		t.Errorf("empty sheet: got %+v, %v", used, err)
+	}
+}
@@ -0,0 +1,130 @@
+package gogsheet
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// NamedRange is a name given to a range of a spreadsheet.
+type NamedRange struct {
+	NamedRangeId string
+	Name         string
+	Range        string // A1 range with sheet name
+}
+
+// namedRanges fetches the named ranges of a spreadsheet with the titles of
+// its sheets by id.
+func (is *Gsheet) namedRanges(spreadsheetId string) ([]*sheets.NamedRange, map[int64]string, error) {
+	resp, err := is.GetMetadataOf(spreadsheetId, "namedRanges,sheets.properties(sheetId,title)")
+	if err != nil {
+		return nil, nil, err
+	}
+	titles := map[int64]string{}
+	for _, sh := range resp.Sheets {
+		titles[sh.Properties.SheetId] = sh.Properties.Title
+	}
+	return resp.NamedRanges, titles, nil
+}
+
+// namedRange looks up the named range name, ignoring case; a nil range
+// means there is none.
+func (is *Gsheet) namedRange(spreadsheetId, name string) (*sheets.NamedRange, *Range, error) {
+	named, titles, err := is.namedRanges(spreadsheetId)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, nr := range named {
+		if strings.EqualFold(nr.Name, name) && nr.Range != nil {
+			return nr, gridToRange(titles[nr.Range.SheetId], nr.Range), nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// ListNamedRanges returns the named ranges of the spreadsheet.
+func (is *Gsheet) ListNamedRanges(sprids ...string) ([]NamedRange, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	named, titles, err := is.namedRanges(spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]NamedRange, 0, len(named))
+	for _, nr := range named {
+		v := NamedRange{NamedRangeId: nr.NamedRangeId, Name: nr.Name}
+		if nr.Range != nil {
+			v.Range = gridToRange(titles[nr.Range.SheetId], nr.Range).String()
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+// AddNamedRange names rangeA1, e.g. "Data!A2:D", and returns the id of the
+// named range. The name can then be used wherever an A1 range is taken.
+func (is *Gsheet) AddNamedRange(name, rangeA1 string, sprids ...string) (string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(name) == 0 || a1RefRegexp.MatchString(name) {
+		return "", fmt.Errorf("invalid range name %q", name)
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return "", err
+	}
+	resp, err := is.Batch(spreadsheetId).AddNamedRange(name, sheetid, r).Do()
+	if err != nil || is.recorder != nil {
+		return "", err
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].AddNamedRange == nil {
+		return "", fmt.Errorf("can not find named range after add")
+	}
+	return resp.Replies[0].AddNamedRange.NamedRange.NamedRangeId, nil
+}
+
+// UpdateNamedRange points the named range name to rangeA1.
+func (is *Gsheet) UpdateNamedRange(name, rangeA1 string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	nr, _, err := is.namedRange(spreadsheetId, name)
+	if err != nil {
+		return err
+	}
+	if nr == nil {
+		return fmt.Errorf("can not find named range %s", name)
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{UpdateNamedRange: &sheets.UpdateNamedRangeRequest{
+		NamedRange: &sheets.NamedRange{NamedRangeId: nr.NamedRangeId, Name: nr.Name, Range: rangeToGrid(sheetid, r)},
+		Fields:     "range",
+	}}).Do()
+	return err
+}
+
+// DeleteNamedRange removes the named range name; the cells are kept.
+func (is *Gsheet) DeleteNamedRange(name string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	nr, _, err := is.namedRange(spreadsheetId, name)
+	if err != nil {
+		return err
+	}
+	if nr == nil {
+		return fmt.Errorf("can not find named range %s", name)
+	}
+	_, err = is.Batch(spreadsheetId).DeleteNamedRange(nr.NamedRangeId).Do()
+	return err
+}
@@ -0,0 +1,174 @@
+package gogsheet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Transform rewrites one cell value, e.g. to mask personal data.
+type Transform func(value string) string
+
+type maskRule struct {
+	sheet   string // "" matches every sheet
+	column  string // header text, matched ignoring case
+	onRead  Transform
+	onWrite Transform
+}
+
+// AddMask applies onRead to the column values of sheetName read through the
+// header-based layers (Get, Handler) and onWrite to the values written
+// through the struct layers. An empty sheetName matches every sheet; either
+// transform may be nil. Empty cells are never transformed. Reads and writes
+// whose header row can not be found fail instead of going out unmasked.
+func (is *Gsheet) AddMask(sheetName, column string, onRead, onWrite Transform) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.masks = append(is.masks, maskRule{sheetName, column, onRead, onWrite})
+}
+
+// ClearMasks removes every mask.
+func (is *Gsheet) ClearMasks() {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.masks = nil
+}
+
+// MaskEmail keeps the first letter and the domain: "john@x.com" -> "j***@x.com".
+func MaskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return strings.Repeat("*", len([]rune(value)))
+	}
+	return string([]rune(value)[:1]) + "***" + value[at:]
+}
+
+// HashID returns a transform replacing values by a keyed hash, stable for a
+// given key so hashed IDs can still be joined.
+func HashID(key string) Transform {
+	return func(value string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil)[:12])
+	}
+}
+
+// Redact returns a transform replacing every match of pattern by repl.
+func Redact(pattern *regexp.Regexp, repl string) Transform {
+	return func(value string) string {
+		return pattern.ReplaceAllString(value, repl)
+	}
+}
+
+// maskTransforms returns the transform of each column of header on
+// sheetName, nil when no mask applies. A nil header, one that could not be
+// established, fails when a mask applies to the sheet rather than leaking
+// the values.
+func (is *Gsheet) maskTransforms(sheetName string, header []string, write bool) ([]Transform, error) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	var ret []Transform
+	for _, m := range is.masks {
+		fn := m.onRead
+		if write {
+			fn = m.onWrite
+		}
+		if fn == nil || (len(m.sheet) != 0 && !strings.EqualFold(m.sheet, sheetName)) {
+			continue
+		}
+		if header == nil {
+			return nil, fmt.Errorf("can not find the header of sheet %s to mask column %s", sheetName, m.column)
+		}
+		for c, h := range header {
+			if !strings.EqualFold(strings.TrimSpace(h), m.column) {
+				continue
+			}
+			if ret == nil {
+				ret = make([]Transform, len(header))
+			}
+			if prev := ret[c]; prev != nil {
+				ret[c] = func(v string) string { return fn(prev(v)) }
+			} else {
+				ret[c] = fn
+			}
+		}
+	}
+	return ret, nil
+}
+
+// maskHeader returns the sheet of readRange and the header its masks match
+// against, with the number of leading rows of the read values to skip. When
+// readRange starts at row 1 the header is first, its first row; otherwise it
+// is read from row 1 of the sheet, cut to the columns of the range, and no
+// row is skipped. The header is nil when row 1 is empty.
+func (is *Gsheet) maskHeader(spreadsheetId, readRange string, first []string) (string, []string, int, error) {
+	is.mutex.Lock()
+	masked := len(is.masks) != 0
+	is.mutex.Unlock()
+	if !masked {
+		return sheetOf(readRange), first, 1, nil
+	}
+	_, r, err := is.gridOf(spreadsheetId, readRange)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	if r.StartRow <= 0 {
+		return r.Sheet, first, 1, nil
+	}
+	rows, err := is.GetValueRange(RowsRange(r.Sheet, 0, 0).String(), spreadsheetId)
+	if errors.Is(err, ErrNoData) || err == nil && len(rows) == 0 {
+		return r.Sheet, nil, 0, nil
+	}
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("header of %s: %w", r.Sheet, err)
+	}
+	header := []string{}
+	if max(r.StartCol, 0) < len(rows[0]) {
+		header = rows[0][max(r.StartCol, 0):]
+	}
+	return r.Sheet, header, 0, nil
+}
+
+// maskValues applies the masks of sheetName in place to rows below header.
+func (is *Gsheet) maskValues(sheetName string, header []string, rows [][]interface{}, write bool) error {
+	fns, err := is.maskTransforms(sheetName, header, write)
+	if fns == nil {
+		return err
+	}
+	for _, row := range rows {
+		for c := range row {
+			if c < len(fns) && fns[c] != nil && row[c] != nil && row[c] != "" {
+				row[c] = fns[c](fmt.Sprint(row[c]))
+			}
+		}
+	}
+	return nil
+}
+
+// maskStrings is maskValues for rows of strings.
+func (is *Gsheet) maskStrings(sheetName string, header []string, rows [][]string, write bool) error {
+	fns, err := is.maskTransforms(sheetName, header, write)
+	if fns == nil {
+		return err
+	}
+	for _, row := range rows {
+		for c := range row {
+			if c < len(fns) && fns[c] != nil && len(row[c]) != 0 {
+				row[c] = fns[c](row[c])
+			}
+		}
+	}
+	return nil
+}
+
+// sheetOf returns the sheet name of an A1 range, "" when it has none.
+func sheetOf(rangeA1 string) string {
+	if r, err := ParseRange(rangeA1); err == nil {
+		return r.Sheet
+	}
+	return ""
+}
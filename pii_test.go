@@ -0,0 +1,21 @@
+package gogsheet
+
+import "testing"
+
+func TestMaskStringsFailsClosed(t *testing.T) {
+	is := &Gsheet{}
+	is.AddMask("Users", "Email", MaskEmail, nil)
+	rows := [][]string{{"john@x.com", "1"}}
+	if err := is.maskStrings("Users", nil, rows, false); err == nil {
+		t.Fatal("masked rows without a header")
+	}
+	if err := is.maskStrings("Other", nil, rows, false); err != nil {
+		t.Fatalf("mask of another sheet applied: %v", err)
+	}
+	if err := is.maskStrings("Users", []string{" email ", "Id"}, rows, false); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0][0] != "j***@x.com" || rows[0][1] != "1" {
+		t.Errorf("got %v", rows[0])
+	}
+}
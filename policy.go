@@ -0,0 +1,206 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPolicy is returned (wrapped) when a mutation touches a guarded range.
+var ErrPolicy = errors.New("write blocked by policy")
+
+// Access is the client side write policy of a guarded range or sheet. It is
+// independent of Google permissions and only protects against this client.
+type Access int
+
+const (
+	ReadWrite Access = iota
+	ReadOnly         // no role may write
+	OwnerOnly        // only a client with RoleOwner may write
+)
+
+func (a Access) String() string {
+	switch a {
+	case ReadOnly:
+		return "read-only"
+	case OwnerOnly:
+		return "owner-only"
+	}
+	return "read-write"
+}
+
+// Role is the role this client acts with when guards are evaluated.
+type Role int
+
+const (
+	RoleEditor Role = iota
+	RoleOwner
+)
+
+type guardRule struct {
+	spreadsheetId string
+	rng           *Range // nil for rules bound to a sheet id
+	sheetId       int64
+	access        Access
+}
+
+func (g guardRule) allows(role Role) bool {
+	return g.access == ReadWrite || (g.access == OwnerOnly && role == RoleOwner)
+}
+
+func (g guardRule) String() string {
+	if g.rng == nil {
+		return fmt.Sprintf("sheet %d is %s", g.sheetId, g.access)
+	}
+	return fmt.Sprintf("%s is %s", g.rng, g.access)
+}
+
+func (is *Gsheet) SetRole(role Role) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.role = role
+}
+
+// GuardRange declares rangeA1 (a range or a bare sheet name) read-only or
+// owner-only, making mutations overlapping it fail with ErrPolicy.
+func (is *Gsheet) GuardRange(rangeA1 string, access Access, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	r, err := ParseRange(rangeA1)
+	if err != nil {
+		return err
+	}
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.guards = append(is.guards, guardRule{spreadsheetId: spreadsheetId, rng: r, access: access})
+	return nil
+}
+
+// GuardSheetId guards a whole sheet by id.
+func (is *Gsheet) GuardSheetId(sheetid int64, access Access, sprids ...string) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.guards = append(is.guards, guardRule{spreadsheetId: spreadsheetId, sheetId: sheetid, access: access})
+}
+
+// ClearGuards removes every guard.
+func (is *Gsheet) ClearGuards() {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	is.guards = nil
+}
+
+// guardsOf returns the rules of a spreadsheet that deny the current role.
+func (is *Gsheet) guardsOf(spreadsheetId string) (rules []guardRule) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	for _, g := range is.guards {
+		if g.spreadsheetId == spreadsheetId && !g.allows(is.role) {
+			rules = append(rules, g)
+		}
+	}
+	return rules
+}
+
+// checkRanges fails with ErrPolicy when one of the A1 ranges overlaps a denying rule.
+// Rules bound to sheet ids are resolved through ListSheets only when present.
+func (is *Gsheet) checkRanges(spreadsheetId string, rangesA1 ...string) error {
+	rules := is.guardsOf(spreadsheetId)
+	if len(rules) == 0 {
+		return nil
+	}
+	var titles map[int64]string
+	for _, a1 := range rangesA1 {
+		target, err := ParseRange(a1)
+		if err != nil {
+			return err
+		}
+		if bareName(a1) {
+			if titles == nil {
+				if titles, err = is.sheetTitles(spreadsheetId); err != nil {
+					return err
+				}
+			}
+			isSheet := false
+			for _, title := range titles {
+				isSheet = isSheet || strings.EqualFold(title, target.Sheet)
+			}
+			if !isSheet {
+				// a named range is checked by the cells it points to
+				_, named, err := is.namedRange(spreadsheetId, a1)
+				if err != nil {
+					return err
+				}
+				if named != nil {
+					target = named
+				}
+			}
+		}
+		for _, g := range rules {
+			rng := g.rng
+			if rng == nil {
+				if titles == nil {
+					if titles, err = is.sheetTitles(spreadsheetId); err != nil {
+						return err
+					}
+				}
+				title, ok := titles[g.sheetId]
+				if !ok {
+					continue
+				}
+				rng = NewRange(title)
+			}
+			if rng.Overlaps(target) {
+				return fmt.Errorf("%w: %s", ErrPolicy, g)
+			}
+		}
+	}
+	return nil
+}
+
+// checkGrid fails with ErrPolicy when target on sheetid overlaps a denying rule.
+func (is *Gsheet) checkGrid(spreadsheetId string, sheetid int64, target *Range) error {
+	rules := is.guardsOf(spreadsheetId)
+	if len(rules) == 0 {
+		return nil
+	}
+	var titles map[int64]string
+	for _, g := range rules {
+		if g.rng == nil {
+			if g.sheetId == sheetid {
+				return fmt.Errorf("%w: %s", ErrPolicy, g)
+			}
+			continue
+		}
+		if titles == nil {
+			var err error
+			if titles, err = is.sheetTitles(spreadsheetId); err != nil {
+				return err
+			}
+		}
+		t := *target
+		t.Sheet = titles[sheetid]
+		if g.rng.Overlaps(&t) {
+			return fmt.Errorf("%w: %s", ErrPolicy, g)
+		}
+	}
+	return nil
+}
+
+func (is *Gsheet) sheetTitles(spreadsheetId string) (map[int64]string, error) {
+	lsheets, err := is.ListSheets(spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	titles := map[int64]string{}
+	for title, id := range lsheets {
+		titles[id] = title
+	}
+	return titles, nil
+}
@@ -0,0 +1,96 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Preset builds the formatting requests of a reusable style for a grid range.
+type Preset func(gr *sheets.GridRange) []*sheets.Request
+
+func repeatFormat(gr *sheets.GridRange, format *sheets.CellFormat, fields string) *sheets.Request {
+	return &sheets.Request{RepeatCell: &sheets.RepeatCellRequest{
+		Range:  gr,
+		Cell:   &sheets.CellData{UserEnteredFormat: format},
+		Fields: fields,
+	}}
+}
+
+// HeaderStyle makes the first row of the range bold on a grey background,
+// centered and wrapped.
+var HeaderStyle Preset = func(gr *sheets.GridRange) []*sheets.Request {
+	header := *gr
+	header.EndRowIndex = header.StartRowIndex + 1
+	return []*sheets.Request{repeatFormat(&header, &sheets.CellFormat{
+		BackgroundColor:     &sheets.Color{Red: 0.85, Green: 0.85, Blue: 0.85},
+		TextFormat:          &sheets.TextFormat{Bold: true},
+		HorizontalAlignment: "CENTER",
+		VerticalAlignment:   "MIDDLE",
+		WrapStrategy:        "WRAP",
+	}, "userEnteredFormat(backgroundColor,textFormat.bold,horizontalAlignment,verticalAlignment,wrapStrategy)")}
+}
+
+// CurrencyColumn formats the range as a right-aligned amount with two
+// decimals, negative amounts in red.
+var CurrencyColumn Preset = CurrencyPreset("#,##0.00")
+
+// CurrencyPreset is CurrencyColumn with a custom number pattern, e.g.
+// `"$"#,##0.00` or `#,##0 "₫"`.
+func CurrencyPreset(pattern string) Preset {
+	return func(gr *sheets.GridRange) []*sheets.Request {
+		return []*sheets.Request{repeatFormat(gr, &sheets.CellFormat{
+			NumberFormat:        &sheets.NumberFormat{Type: "CURRENCY", Pattern: fmt.Sprintf("%s;[Red]-%s", pattern, pattern)},
+			HorizontalAlignment: "RIGHT",
+		}, "userEnteredFormat(numberFormat,horizontalAlignment)")}
+	}
+}
+
+// WarningCell highlights the range with dark red bold text on a light red
+// background.
+var WarningCell Preset = func(gr *sheets.GridRange) []*sheets.Request {
+	return []*sheets.Request{repeatFormat(gr, &sheets.CellFormat{
+		BackgroundColor: &sheets.Color{Red: 0.96, Green: 0.8, Blue: 0.8},
+		TextFormat:      &sheets.TextFormat{Bold: true, ForegroundColor: &sheets.Color{Red: 0.6}},
+	}, "userEnteredFormat(backgroundColor,textFormat(bold,foregroundColor))")}
+}
+
+// ZebraTable bands the rows of the range in alternating colors with a
+// distinct header row. A range can only be banded once; clear it with
+// RemoveBanding before applying it again.
+var ZebraTable Preset = BandingPreset(BlueBanding)
+
+// Presets combines several presets into one, applied in order.
+func Presets(presets ...Preset) Preset {
+	return func(gr *sheets.GridRange) []*sheets.Request {
+		reqs := []*sheets.Request{}
+		for _, p := range presets {
+			g := *gr
+			reqs = append(reqs, p(&g)...)
+		}
+		return reqs
+	}
+}
+
+// Preset queues the requests of preset for r on the sheet with id sheetid.
+func (b *Batch) Preset(sheetid int64, r *Range, preset Preset) *Batch {
+	b.checks = append(b.checks, func() error {
+		return b.is.checkGrid(b.spreadsheetId, sheetid, r)
+	})
+	return b.Raw(preset(rangeToGrid(sheetid, r))...)
+}
+
+// ApplyPreset formats rangeA1 with preset in a single batchUpdate, e.g.
+// ApplyPreset("Sheet1!A1:F", Presets(ZebraTable, HeaderStyle)).
+func (is *Gsheet) ApplyPreset(rangeA1 string, preset Preset, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Preset(sheetid, r, preset).Do()
+	return err
+}
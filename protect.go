@@ -0,0 +1,115 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ProtectedRange is a range, or whole sheet, locked against edits in the
+// spreadsheet UI. Unlike GuardRange it is enforced by Google for every user.
+type ProtectedRange struct {
+	ProtectedRangeId int64
+	Range            string // A1 range with sheet name
+	Description      string
+	Editors          []string // users allowed to edit besides the owner
+	WarningOnly      bool     // edits are allowed after a warning
+}
+
+// protectedRangeFields builds the editable fields of a protected range and
+// their mask. Editors can not be set on warning-only protections.
+func protectedRangeFields(pr *sheets.ProtectedRange, description string, editors []string, warningOnly bool) (string, error) {
+	if warningOnly && len(editors) != 0 {
+		return "", fmt.Errorf("a warning-only protection can not have editors")
+	}
+	pr.Description = description
+	pr.WarningOnly = warningOnly
+	pr.ForceSendFields = []string{"Description", "WarningOnly"}
+	if warningOnly {
+		return "description,warningOnly", nil
+	}
+	pr.Editors = &sheets.Editors{Users: editors}
+	return "description,warningOnly,editors", nil
+}
+
+// ProtectRange locks rangeA1, e.g. "Report!D:D" or a sheet name, so only the
+// owner, the caller and editors can change it; with warningOnly everyone can
+// after confirming a warning. It returns the id of the protected range.
+func (is *Gsheet) ProtectRange(rangeA1, description string, editors []string, warningOnly bool, sprids ...string) (int64, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	pr := &sheets.ProtectedRange{Range: rangeToGrid(sheetid, r)}
+	if _, err = protectedRangeFields(pr, description, editors, warningOnly); err != nil {
+		return 0, err
+	}
+	resp, err := is.Batch(spreadsheetId).Raw(&sheets.Request{AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: pr}}).Do()
+	if err != nil || is.recorder != nil {
+		return 0, err
+	}
+	if len(resp.Replies) == 0 || resp.Replies[0].AddProtectedRange == nil {
+		return 0, fmt.Errorf("can not find protected range after add")
+	}
+	return resp.Replies[0].AddProtectedRange.ProtectedRange.ProtectedRangeId, nil
+}
+
+// UpdateProtectedRange replaces the description, editors and warning mode of
+// the protected range with id protectedRangeId.
+func (is *Gsheet) UpdateProtectedRange(protectedRangeId int64, description string, editors []string, warningOnly bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	pr := &sheets.ProtectedRange{ProtectedRangeId: protectedRangeId}
+	fields, err := protectedRangeFields(pr, description, editors, warningOnly)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{UpdateProtectedRange: &sheets.UpdateProtectedRangeRequest{
+		ProtectedRange: pr,
+		Fields:         fields,
+	}}).Do()
+	return err
+}
+
+// ListProtectedRanges returns the protected ranges of every sheet. Editors
+// are only filled in when the caller may edit the protection.
+func (is *Gsheet) ListProtectedRanges(sprids ...string) ([]ProtectedRange, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	resp, err := is.GetMetadataOf(spreadsheetId, "sheets(properties(sheetId,title),protectedRanges)")
+	if err != nil {
+		return nil, err
+	}
+	ret := []ProtectedRange{}
+	for _, sh := range resp.Sheets {
+		for _, pr := range sh.ProtectedRanges {
+			v := ProtectedRange{ProtectedRangeId: pr.ProtectedRangeId, Description: pr.Description, WarningOnly: pr.WarningOnly}
+			if pr.Range != nil {
+				v.Range = gridToRange(sh.Properties.Title, pr.Range).String()
+			}
+			if pr.Editors != nil {
+				v.Editors = pr.Editors.Users
+			}
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// Unprotect removes the protected range with id protectedRangeId.
+func (is *Gsheet) Unprotect(protectedRangeId int64, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	_, err := is.Batch(spreadsheetId).Raw(&sheets.Request{DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{ProtectedRangeId: protectedRangeId}}).Do()
+	return err
+}
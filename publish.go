@@ -0,0 +1,121 @@
+package gogsheet
+
+import (
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ViewTransform projects working data into a view: it may filter, sort and
+// drop columns. A nil transform publishes the data as is.
+type ViewTransform func(header []string, rows [][]string) ([]string, [][]string)
+
+// PublishView copies the projection of srcRange (first row is the header)
+// to viewSheet as text, written over the previous view rather than after
+// clearing it, so readers never see an empty view; large views are written
+// in several calls, see WriteOptions.ChunkRows. viewSheet is created
+// when missing and protected so that only the owner and this client can
+// edit it.
+func (is *Gsheet) PublishView(srcRange, viewSheet string, transform ViewTransform, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	rows, err := is.GetValueRange(srcRange, spreadsheetId)
+	if err != nil {
+		return err
+	}
+	var header []string
+	var data [][]string
+	if len(rows) != 0 {
+		header, data = rows[0], rows[1:]
+	}
+	if transform != nil {
+		header, data = transform(header, data)
+	}
+	if err = is.ensureViewSheet(spreadsheetId, viewSheet); err != nil {
+		return err
+	}
+	prev, err := is.getValues(spreadsheetId, NewRange(viewSheet).String(), ReadOptions{})
+	if err != nil {
+		return err
+	}
+	width := len(header)
+	for _, row := range prev.Values {
+		width = max(width, len(row))
+	}
+	// pad over the previous view instead of clearing it first
+	out := make([][]interface{}, 0, max(len(data)+1, len(prev.Values)))
+	for _, row := range append([][]string{header}, data...) {
+		cells := make([]interface{}, width)
+		for c := range cells {
+			cells[c] = ""
+			if c < len(row) {
+				cells[c] = row[c]
+			}
+		}
+		out = append(out, cells)
+	}
+	for len(out) < len(prev.Values) {
+		cells := make([]interface{}, width)
+		for c := range cells {
+			cells[c] = ""
+		}
+		out = append(out, cells)
+	}
+	// formatted values are copied raw so "=x", "0012" or "1/2" stay text
+	return is.UpdateRangeWith(out, CellRange(viewSheet, 0, 0).String(), WriteOptions{ValueInputOption: Raw}, spreadsheetId)
+}
+
+// ensureViewSheet creates viewSheet when missing and protects it when no
+// protected range covers the whole sheet yet.
+func (is *Gsheet) ensureViewSheet(spreadsheetId, viewSheet string) error {
+	resp, err := is.GetMetadataOf(spreadsheetId, "sheets(properties(sheetId,title),protectedRanges(range))")
+	if err != nil {
+		return err
+	}
+	var sheetid int64 = -1
+	protected := false
+	for _, sh := range resp.Sheets {
+		if sh.Properties.Title != viewSheet {
+			continue
+		}
+		sheetid = sh.Properties.SheetId
+		for _, pr := range sh.ProtectedRanges {
+			if r := pr.Range; r != nil && r.StartRowIndex == 0 && r.EndRowIndex == 0 && r.StartColumnIndex == 0 && r.EndColumnIndex == 0 {
+				protected = true
+			}
+		}
+	}
+	if sheetid < 0 {
+		if sheetid, err = is.CreaateSheet(viewSheet, spreadsheetId); err != nil {
+			return err
+		}
+	}
+	if protected {
+		return nil
+	}
+	_, err = is.Batch(spreadsheetId).Raw(&sheets.Request{AddProtectedRange: &sheets.AddProtectedRangeRequest{
+		ProtectedRange: &sheets.ProtectedRange{Range: &sheets.GridRange{SheetId: sheetid}, Description: "published view"},
+	}}).Do()
+	return err
+}
+
+// ViewPublisher republishes a view on a schedule.
+type ViewPublisher struct {
+	*poller
+}
+
+// PublishViewEvery runs PublishView now and every interval until Stop or
+// Close. Errors are passed to onError when set.
+func (is *Gsheet) PublishViewEvery(srcRange, viewSheet string, transform ViewTransform, interval time.Duration, onError func(error), sprids ...string) (*ViewPublisher, error) {
+	p, err := is.startPoller(interval, func() {
+		if err := is.PublishView(srcRange, viewSheet, transform, sprids...); err != nil && onError != nil {
+			onError(err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ViewPublisher{p}, nil
+}
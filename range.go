@@ -0,0 +1,319 @@
+package gogsheet
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// ColumnLetter converts a zero-based column index to its A1 letters (0 -> "A", 26 -> "AA").
+func ColumnLetter(col int) string {
+	if col < 0 {
+		return ""
+	}
+	b := []byte{}
+	for col >= 0 {
+		b = append([]byte{byte('A' + col%26)}, b...)
+		col = col/26 - 1
+	}
+	return string(b)
+}
+
+// ColumnIndex converts A1 column letters to a zero-based column index ("A" -> 0, "AA" -> 26).
+func ColumnIndex(letters string) (int, error) {
+	if len(letters) == 0 {
+		return -1, fmt.Errorf("empty column letters")
+	}
+	col := 0
+	for _, c := range strings.ToUpper(letters) {
+		if c < 'A' || c > 'Z' {
+			return -1, fmt.Errorf("invalid column letters %q", letters)
+		}
+		col = col*26 + int(c-'A') + 1
+	}
+	return col - 1, nil
+}
+
+// QuoteSheetName quotes a sheet title for use in A1 notation when needed.
+func QuoteSheetName(sheetName string) string {
+	if len(sheetName) == 0 {
+		return ""
+	}
+	for _, c := range sheetName {
+		if !(c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+		}
+	}
+	return sheetName
+}
+
+// Range describes an A1 range with zero-based, inclusive bounds.
+// A negative bound is open: StartRow/EndRow < 0 selects whole columns ("B:B"),
+// StartCol/EndCol < 0 selects whole rows ("2:5"), and EndRow < 0 with a
+// StartRow set reads everything below the start row ("A2:D").
+type Range struct {
+	Sheet    string
+	StartCol int
+	StartRow int
+	EndCol   int
+	EndRow   int
+}
+
+// NewRange returns a range covering the whole sheet.
+func NewRange(sheetName string) *Range {
+	return &Range{Sheet: sheetName, StartCol: -1, StartRow: -1, EndCol: -1, EndRow: -1}
+}
+
+// CellRange returns the range of a single cell.
+func CellRange(sheetName string, col, row int) *Range {
+	return &Range{Sheet: sheetName, StartCol: col, StartRow: row, EndCol: col, EndRow: row}
+}
+
+// OpenRange returns the range from (startCol, startRow) to endCol without a
+// bottom bound, e.g. OpenRange("Data", 0, 1, 3) is "Data!A2:D".
+func OpenRange(sheetName string, startCol, startRow, endCol int) *Range {
+	return &Range{Sheet: sheetName, StartCol: startCol, StartRow: startRow, EndCol: endCol, EndRow: -1}
+}
+
+// ColumnsRange returns whole columns, e.g. ColumnsRange("Data", 1, 1) is "Data!B:B".
+func ColumnsRange(sheetName string, startCol, endCol int) *Range {
+	return &Range{Sheet: sheetName, StartCol: startCol, StartRow: -1, EndCol: endCol, EndRow: -1}
+}
+
+// RowsRange returns whole rows, e.g. RowsRange("Data", 1, 4) is "Data!2:5".
+func RowsRange(sheetName string, startRow, endRow int) *Range {
+	return &Range{Sheet: sheetName, StartCol: -1, StartRow: startRow, EndCol: -1, EndRow: endRow}
+}
+
+// From sets the top-left corner of the range.
+func (r *Range) From(col, row int) *Range {
+	r.StartCol, r.StartRow = col, row
+	return r
+}
+
+// To sets the bottom-right corner of the range; pass -1 to leave a side open.
+func (r *Range) To(col, row int) *Range {
+	r.EndCol, r.EndRow = col, row
+	return r
+}
+
+func cellPart(col, row int) string {
+	s := ColumnLetter(col)
+	if row >= 0 {
+		s += strconv.Itoa(row + 1)
+	}
+	return s
+}
+
+// String renders the range in A1 notation.
+func (r *Range) String() string {
+	sheet := QuoteSheetName(r.Sheet)
+	start := cellPart(r.StartCol, r.StartRow)
+	end := cellPart(r.EndCol, r.EndRow)
+	if len(start) == 0 && len(end) == 0 {
+		return sheet
+	}
+	if len(start) == 0 {
+		start = end
+	}
+	if len(end) == 0 {
+		end = start
+	}
+	a1 := start + ":" + end
+	if start == end && r.StartCol >= 0 && r.StartRow >= 0 {
+		a1 = start
+	}
+	if len(sheet) == 0 {
+		return a1
+	}
+	return sheet + "!" + a1
+}
+
+// a1RefRegexp matches a cell ("C3") or a span with a colon ("A:A", "1:1",
+// "A2:D"); a lone run of letters or digits is a name.
+var a1RefRegexp = regexp.MustCompile(`^(\$?[A-Za-z]{1,3}\$?[0-9]+|\$?[A-Za-z]{0,3}\$?[0-9]*:\$?[A-Za-z]{0,3}\$?[0-9]*)$`)
+
+// bareName reports whether a1 is a name alone, a sheet or a named range.
+func bareName(a1 string) bool {
+	return len(a1) != 0 && !strings.Contains(a1, "!") && !a1RefRegexp.MatchString(a1)
+}
+
+// ParseRange parses an A1 range such as "Data!A2:D", "'My Sheet'!B:B" or "C3".
+// A string without "!" that is not a cell reference is taken as a sheet name,
+// so "Log" is a sheet while column LOG is written "LOG:LOG".
+func ParseRange(a1 string) (*Range, error) {
+	r := NewRange("")
+	if i := strings.LastIndex(a1, "!"); i >= 0 {
+		r.Sheet = a1[:i]
+		a1 = a1[i+1:]
+		if len(r.Sheet) >= 2 && r.Sheet[0] == '\'' && r.Sheet[len(r.Sheet)-1] == '\'' {
+			r.Sheet = strings.ReplaceAll(r.Sheet[1:len(r.Sheet)-1], "''", "'")
+		}
+		if len(a1) != 0 && !a1RefRegexp.MatchString(a1) {
+			return nil, fmt.Errorf("invalid range %q", a1)
+		}
+	} else if !a1RefRegexp.MatchString(a1) {
+		r.Sheet = strings.Trim(a1, "'")
+		return r, nil
+	}
+	if len(a1) == 0 {
+		return r, nil
+	}
+	parts := strings.SplitN(a1, ":", 2)
+	var err error
+	if r.StartCol, r.StartRow, err = parseCell(parts[0]); err != nil {
+		return nil, err
+	}
+	if len(parts) == 1 {
+		r.EndCol, r.EndRow = r.StartCol, r.StartRow
+		return r, nil
+	}
+	if r.EndCol, r.EndRow, err = parseCell(parts[1]); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// sheetCell returns the range of the single cell cellAddress, e.g. "B2", of
+// sheetName.
+func sheetCell(sheetName, cellAddress string) (*Range, error) {
+	col, row, err := parseCell(cellAddress)
+	if err != nil {
+		return nil, err
+	}
+	if col < 0 || row < 0 {
+		return nil, fmt.Errorf("invalid cell reference %q", cellAddress)
+	}
+	return CellRange(sheetName, col, row), nil
+}
+
+func parseCell(s string) (col, row int, err error) {
+	s = strings.ReplaceAll(s, "$", "")
+	i := 0
+	for i < len(s) && (s[i] < '0' || s[i] > '9') {
+		i++
+	}
+	col, row = -1, -1
+	if i > 0 {
+		if col, err = ColumnIndex(s[:i]); err != nil {
+			return -1, -1, err
+		}
+	}
+	if i < len(s) {
+		n, err := strconv.Atoi(s[i:])
+		if err != nil || n < 1 {
+			return -1, -1, fmt.Errorf("invalid cell reference %q", s)
+		}
+		row = n - 1
+	}
+	if col < 0 && row < 0 {
+		return -1, -1, fmt.Errorf("invalid cell reference %q", s)
+	}
+	return col, row, nil
+}
+
+// TrimPolicy controls how blank trailing data is cleaned from read results.
+// The API already drops fully empty trailing rows and cells, but cells holding
+// "" or whitespace (e.g. formulas returning "") are still returned.
+type TrimPolicy int
+
+const (
+	// TrimNone returns rows exactly as the API sends them.
+	TrimNone TrimPolicy = 0
+	// TrimTrailingBlankRows drops trailing rows whose cells are all blank.
+	TrimTrailingBlankRows TrimPolicy = 1 << (iota - 1)
+	// TrimTrailingBlankCells drops trailing blank cells of each row.
+	TrimTrailingBlankCells
+	// PadRows pads every row with "" to the width of the widest row.
+	PadRows
+	// TrimTrailing combines TrimTrailingBlankRows and TrimTrailingBlankCells.
+	TrimTrailing = TrimTrailingBlankRows | TrimTrailingBlankCells
+)
+
+func isBlank(s string) bool {
+	return len(strings.TrimSpace(s)) == 0
+}
+
+// TrimRows applies policy to rows in place and returns the result.
+func TrimRows(rows [][]string, policy TrimPolicy) [][]string {
+	if policy&TrimTrailingBlankCells != 0 {
+		for i, row := range rows {
+			n := len(row)
+			for n > 0 && isBlank(row[n-1]) {
+				n--
+			}
+			rows[i] = row[:n]
+		}
+	}
+	if policy&TrimTrailingBlankRows != 0 {
+		n := len(rows)
+	loop:
+		for n > 0 {
+			for _, s := range rows[n-1] {
+				if !isBlank(s) {
+					break loop
+				}
+			}
+			n--
+		}
+		rows = rows[:n]
+	}
+	if policy&PadRows != 0 {
+		width := 0
+		for _, row := range rows {
+			if len(row) > width {
+				width = len(row)
+			}
+		}
+		for i, row := range rows {
+			for len(row) < width {
+				row = append(row, "")
+			}
+			rows[i] = row
+		}
+	}
+	return rows
+}
+
+// spans reports whether the inclusive intervals [s1,e1] and [s2,e2] overlap;
+// negative bounds are open.
+func spans(s1, e1, s2, e2 int) bool {
+	return (e1 < 0 || s2 < 0 || s2 <= e1) && (e2 < 0 || s1 < 0 || s1 <= e2)
+}
+
+// Overlaps reports whether r and o share at least one cell. Sheet names are
+// compared case-insensitively and a range without sheet name matches any sheet.
+func (r *Range) Overlaps(o *Range) bool {
+	if len(r.Sheet) != 0 && len(o.Sheet) != 0 && !strings.EqualFold(r.Sheet, o.Sheet) {
+		return false
+	}
+	return spans(r.StartCol, r.EndCol, o.StartCol, o.EndCol) && spans(r.StartRow, r.EndRow, o.StartRow, o.EndRow)
+}
+
+// gridToRange converts a GridRange (exclusive ends, zero end meaning unbounded)
+// to a Range on sheetName.
+func gridToRange(sheetName string, gr *sheets.GridRange) *Range {
+	r := &Range{Sheet: sheetName, StartCol: int(gr.StartColumnIndex), StartRow: int(gr.StartRowIndex), EndCol: -1, EndRow: -1}
+	if gr.EndColumnIndex > 0 {
+		r.EndCol = int(gr.EndColumnIndex) - 1
+	}
+	if gr.EndRowIndex > 0 {
+		r.EndRow = int(gr.EndRowIndex) - 1
+	}
+	return r
+}
+
+// rangeToGrid converts r to a GridRange on sheetid, the inverse of gridToRange.
+func rangeToGrid(sheetid int64, r *Range) *sheets.GridRange {
+	gr := &sheets.GridRange{SheetId: sheetid, StartColumnIndex: int64(max(r.StartCol, 0)), StartRowIndex: int64(max(r.StartRow, 0))}
+	if r.EndCol >= 0 {
+		gr.EndColumnIndex = int64(r.EndCol) + 1
+	}
+	if r.EndRow >= 0 {
+		gr.EndRowIndex = int64(r.EndRow) + 1
+	}
+	return gr
+}
@@ -0,0 +1,57 @@
+package gogsheet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		a1   string
+		want *Range
+		bare bool
+	}{
+		{"Log", NewRange("Log"), true},
+		{"Tab", NewRange("Tab"), true},
+		{"A", NewRange("A"), true},
+		{"2024", NewRange("2024"), true},
+		{"Q1x", NewRange("Q1x"), true},
+		{"A1", CellRange("", 0, 0), false},
+		{"A:A", ColumnsRange("", 0, 0), false},
+		{"1:1", RowsRange("", 0, 0), false},
+		{"LOG:LOG", ColumnsRange("", 8508, 8508), false},
+		{"Data!A2:D", OpenRange("Data", 0, 1, 3), false},
+		{"'My Sheet'!B:B", ColumnsRange("My Sheet", 1, 1), false},
+	}
+	for _, tt := range tests {
+		got, err := ParseRange(tt.a1)
+		if err != nil {
+			t.Errorf("ParseRange(%q): %v", tt.a1, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRange(%q) = %+v, want %+v", tt.a1, got, tt.want)
+		}
+		if bareName(tt.a1) != tt.bare {
+			t.Errorf("bareName(%q) = %v, want %v", tt.a1, !tt.bare, tt.bare)
+		}
+	}
+	if _, err := ParseRange("Data!Log"); err == nil {
+		t.Errorf("ParseRange(%q) accepted a name after the sheet", "Data!Log")
+	}
+}
+
+func TestSheetCell(t *testing.T) {
+	r, err := sheetCell("My Sheet", "B2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.String(); got != "'My Sheet'!B2" {
+		t.Errorf("got %s", got)
+	}
+	for _, bad := range []string{"B", "2", "B2:C3", ""} {
+		if _, err := sheetCell("Data", bad); err == nil {
+			t.Errorf("%q accepted as a cell", bad)
+		}
+	}
+}
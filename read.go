@@ -0,0 +1,337 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value render options for reads.
+const (
+	FormattedValue   = "FORMATTED_VALUE"
+	UnformattedValue = "UNFORMATTED_VALUE"
+	Formula          = "FORMULA"
+)
+
+// Date time render options, only used when values are not FORMATTED_VALUE.
+const (
+	SerialNumber    = "SERIAL_NUMBER"
+	FormattedString = "FORMATTED_STRING"
+)
+
+// Major dimensions of value ranges.
+const (
+	DimensionRows    = "ROWS"
+	DimensionColumns = "COLUMNS"
+)
+
+// ReadOptions controls how values are rendered by reads. Empty fields leave
+// the API defaults (FORMATTED_VALUE, SERIAL_NUMBER, ROWS).
+type ReadOptions struct {
+	ValueRenderOption    string
+	DateTimeRenderOption string
+	MajorDimension       string
+}
+
+// SetReadOptions sets the options used by GetValueRange, GetValueRanges and GetValueCell.
+func (is *Gsheet) SetReadOptions(opts ReadOptions) {
+	is.readOptions = opts
+}
+
+// GetColumns reads readRange column by column: ret[i] is the i-th column.
+func (is *Gsheet) GetColumns(readRange string, sprids ...string) ([][]string, error) {
+	opts := is.readOptions
+	opts.MajorDimension = DimensionColumns
+	return is.GetValueRangeWith(readRange, opts, sprids...)
+}
+
+// GetColumn reads a single column range such as "Data!A2:A" as one slice.
+func (is *Gsheet) GetColumn(readRange string, sprids ...string) ([]string, error) {
+	cols, err := is.GetColumns(readRange, sprids...)
+	if err != nil || len(cols) == 0 {
+		return []string{}, err
+	}
+	return cols[0], nil
+}
+
+// GetFormulas returns the formula text of every cell of readRange, e.g.
+// "=SUM(A1:A9)"; cells without formula hold their plain value.
+func (is *Gsheet) GetFormulas(readRange string, sprids ...string) ([][]string, error) {
+	opts := is.readOptions
+	opts.ValueRenderOption = Formula
+	return is.GetValueRangeWith(readRange, opts, sprids...)
+}
+
+// GetValues reads readRange keeping the API's native types: float64 for
+// numbers and serial dates, bool for checkboxes and string for text.
+func (is *Gsheet) GetValues(readRange string, sprids ...string) ([][]interface{}, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	opts := is.readOptions
+	opts.ValueRenderOption = UnformattedValue
+	resp, err := is.getValues(spreadsheetId, readRange, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Values) == 0 {
+		return [][]interface{}{}, is.noData()
+	}
+	return resp.Values, nil
+}
+
+// GetCellValue returns the native typed value of one cell, see GetValues.
+func (is *Gsheet) GetCellValue(sheetname, cellAddress string, sprids ...string) (interface{}, error) {
+	rets, err := is.GetValues(fmt.Sprintf("%s!%s:%s", sheetname, cellAddress, cellAddress), sprids...)
+	if err != nil {
+		return nil, err
+	}
+	if len(rets) == 0 || len(rets[0]) == 0 {
+		return nil, fmt.Errorf("not found")
+	}
+	return rets[0][0], nil
+}
+
+// ErrNoData is returned by reads of a range without values, unless
+// SetEmptyResults is on.
+var ErrNoData = errors.New("no data found")
+
+// SetEmptyResults makes reads of an empty range return an empty, non-nil
+// result and a nil error instead of ErrNoData.
+func (is *Gsheet) SetEmptyResults(on bool) {
+	is.emptyResults = on
+}
+
+// noData is the error of a read that found no values.
+func (is *Gsheet) noData() error {
+	if is.emptyResults {
+		return nil
+	}
+	return ErrNoData
+}
+
+// ErrEmptyCell is returned by the typed cell getters for a blank cell.
+var ErrEmptyCell = errors.New("cell is empty")
+
+// decimalCommaLanguages use "," as decimal separator.
+var decimalCommaLanguages = map[string]bool{
+	"de": true, "fr": true, "es": true, "it": true, "pt": true, "nl": true, "ru": true, "vi": true,
+	"id": true, "tr": true, "pl": true, "cs": true, "sk": true, "sv": true, "da": true, "nb": true,
+	"fi": true, "uk": true, "ro": true, "hu": true, "el": true, "bg": true, "hr": true, "sl": true,
+}
+
+// ParseLocaleFloat parses a number formatted for locale (e.g. "de_DE" "1.234,5"),
+// ignoring grouping separators, currency symbols and a trailing percent sign.
+// Accounting negatives such as "(1,234)" are negative and an e or E is only
+// an exponent between a digit and a digit or sign, so "EUR 5" is 5.
+func ParseLocaleFloat(s, locale string) (float64, error) {
+	lang := strings.ToLower(strings.SplitN(strings.SplitN(locale, "_", 2)[0], "-", 2)[0])
+	decimal := '.'
+	if decimalCommaLanguages[lang] {
+		decimal = ','
+	}
+	trimmed := strings.TrimSpace(s)
+	percent := strings.HasSuffix(trimmed, "%")
+	open, end := strings.Index(trimmed, "("), strings.LastIndex(trimmed, ")")
+	negative := open >= 0 && end > open
+	v := []rune(trimmed)
+	digit := func(i int) bool { return i >= 0 && i < len(v) && v[i] >= '0' && v[i] <= '9' }
+	var b strings.Builder
+	for i, r := range v {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == '+':
+			b.WriteRune(r)
+		case r == decimal:
+			b.WriteRune('.')
+		case (r == 'e' || r == 'E') && (digit(i-1) || i > 0 && v[i-1] == decimal) &&
+			(digit(i+1) || i+1 < len(v) && (v[i+1] == '-' || v[i+1] == '+') && digit(i+2)):
+			b.WriteRune(r)
+		}
+		// grouping separators, spaces, parentheses and currency symbols are dropped
+	}
+	f, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if negative {
+		f = -f
+	}
+	if percent {
+		f /= 100
+	}
+	return f, nil
+}
+
+// typedCell returns the spreadsheet id and native value of one cell, failing
+// with ErrEmptyCell for blank cells.
+func (is *Gsheet) typedCell(sheetname, cellAddress string, sprids ...string) (string, interface{}, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	opts := is.readOptions
+	opts.ValueRenderOption = UnformattedValue
+	resp, err := is.getValues(spreadsheetId, fmt.Sprintf("%s!%s:%s", sheetname, cellAddress, cellAddress), opts)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(resp.Values) == 0 || len(resp.Values[0]) == 0 {
+		return "", nil, fmt.Errorf("%s!%s: %w", sheetname, cellAddress, ErrEmptyCell)
+	}
+	v := resp.Values[0][0]
+	if s, ok := v.(string); ok && isBlank(s) {
+		return "", nil, fmt.Errorf("%s!%s: %w", sheetname, cellAddress, ErrEmptyCell)
+	}
+	return spreadsheetId, v, nil
+}
+
+// spreadsheetLocale returns (and caches) the locale and time zone of a spreadsheet.
+func (is *Gsheet) spreadsheetLocale(spreadsheetId string) (*SpreadsheetInfo, error) {
+	is.mutex.Lock()
+	info, ok := is.locales[spreadsheetId]
+	is.mutex.Unlock()
+	if ok {
+		return info, nil
+	}
+	info, err := is.describeSpreadsheet(spreadsheetId)
+	if err != nil {
+		return nil, err
+	}
+	is.mutex.Lock()
+	if is.locales == nil {
+		is.locales = map[string]*SpreadsheetInfo{}
+	}
+	is.locales[spreadsheetId] = info
+	is.mutex.Unlock()
+	return info, nil
+}
+
+func (is *Gsheet) GetCellFloat(sheetname, cellAddress string, sprids ...string) (float64, error) {
+	spreadsheetId, v, err := is.typedCell(sheetname, cellAddress, sprids...)
+	if err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		info, err := is.spreadsheetLocale(spreadsheetId)
+		if err != nil {
+			return 0, err
+		}
+		f, err := ParseLocaleFloat(v, info.Locale)
+		if err != nil {
+			return 0, fmt.Errorf("%s!%s: %w", sheetname, cellAddress, err)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("%s!%s: %v is not a number", sheetname, cellAddress, v)
+}
+
+func (is *Gsheet) GetCellInt(sheetname, cellAddress string, sprids ...string) (int64, error) {
+	f, err := is.GetCellFloat(sheetname, cellAddress, sprids...)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, fmt.Errorf("%s!%s: %v is not an integer", sheetname, cellAddress, f)
+	}
+	return int64(f), nil
+}
+
+// GetCellBool reads checkbox cells and TRUE/FALSE, yes/no, 1/0 text.
+func (is *Gsheet) GetCellBool(sheetname, cellAddress string, sprids ...string) (bool, error) {
+	_, v, err := is.typedCell(sheetname, cellAddress, sprids...)
+	if err != nil {
+		return false, err
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "y", "1", "x":
+			return true, nil
+		case "false", "no", "n", "0":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("%s!%s: %v is not a boolean", sheetname, cellAddress, v)
+}
+
+// GetCellTime reads date/time cells; serial values are interpreted in the
+// spreadsheet's time zone, text is parsed with TimeLayouts.
+func (is *Gsheet) GetCellTime(sheetname, cellAddress string, sprids ...string) (time.Time, error) {
+	spreadsheetId, v, err := is.typedCell(sheetname, cellAddress, sprids...)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := cellTime(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s!%s: %w", sheetname, cellAddress, err)
+	}
+	if _, ok := v.(float64); ok {
+		info, err := is.spreadsheetLocale(spreadsheetId)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if loc, err := time.LoadLocation(info.TimeZone); err == nil {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+		}
+	}
+	return t, nil
+}
+
+// stringRows flattens native values to strings the way GetValueRange does.
+func stringRows(values [][]interface{}) [][]string {
+	ret := make([][]string, 0, len(values))
+	for _, row := range values {
+		col := make([]string, 0, len(row))
+		for _, s := range row {
+			col = append(col, fmt.Sprint(s))
+		}
+		ret = append(ret, col)
+	}
+	return ret
+}
+
+// GetRecords reads sheetName with the first row as header and returns every
+// following row as a map keyed by header; missing trailing cells map to "".
+func (is *Gsheet) GetRecords(sheetName string, sprids ...string) ([]map[string]string, error) {
+	rows, err := is.GetValueRange(NewRange(sheetName).String(), sprids...)
+	if err != nil || len(rows) == 0 {
+		return []map[string]string{}, err
+	}
+	if err = is.maskStrings(sheetName, rows[0], rows[1:], false); err != nil {
+		return nil, err
+	}
+	return rowsToRecords(rows), nil
+}
+
+// rowsToRecords maps the rows below rows[0] by header.
+func rowsToRecords(rows [][]string) []map[string]string {
+	records := []map[string]string{}
+	if len(rows) == 0 {
+		return records
+	}
+	for _, row := range rows[1:] {
+		rec := make(map[string]string, len(rows[0]))
+		for i, h := range rows[0] {
+			if i < len(row) {
+				rec[h] = row[i]
+			} else {
+				rec[h] = ""
+			}
+		}
+		records = append(records, rec)
+	}
+	return records
+}
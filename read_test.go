@@ -0,0 +1,30 @@
+package gogsheet
+
+import "testing"
+
+func TestParseLocaleFloat(t *testing.T) {
+	tests := []struct {
+		s, locale string
+		want      float64
+	}{
+		{"1,234.5", "en_US", 1234.5},
+		{"1.234,5", "de_DE", 1234.5},
+		{"EUR 5", "en_US", 5},
+		{"5 EUR", "de_DE", 5},
+		{"(1,234)", "en_US", -1234},
+		{"$(1,234.50)", "en_US", -1234.5},
+		{"-3", "en_US", -3},
+		{"3e5", "en_US", 300000},
+		{"1.5E-2", "en_US", 0.015},
+		{"12,5%", "fr_FR", 0.125},
+	}
+	for _, tt := range tests {
+		got, err := ParseLocaleFloat(tt.s, tt.locale)
+		if err != nil || got != tt.want {
+			t.Errorf("%q (%s): got %v, %v, want %v", tt.s, tt.locale, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLocaleFloat("E", "en_US"); err == nil {
+		t.Error(`"E" parsed as a number`)
+	}
+}
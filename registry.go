@@ -0,0 +1,229 @@
+package gogsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	spreadsheetMimeType = "application/vnd.google-apps.spreadsheet"
+	folderMimeType      = "application/vnd.google-apps.folder"
+)
+
+// CatalogEntry describes one spreadsheet found by a Registry scan.
+type CatalogEntry struct {
+	Name          string // logical name used for lookups
+	SpreadsheetId string
+	Path          string // folder path relative to the scanned root
+	ModifiedTime  time.Time
+	Tabs          []string
+	Headers       map[string][]string // tab title -> first row
+}
+
+// Registry is a catalog of the spreadsheets living under a Drive folder tree,
+// addressable by logical name.
+type Registry struct {
+	// NameFunc derives the logical name of an entry, by default its file name.
+	// Entries with the same logical name: the most recently modified wins.
+	NameFunc func(e *CatalogEntry) string
+
+	is      *Gsheet
+	mutex   sync.RWMutex
+	entries map[string]*CatalogEntry
+}
+
+func (is *Gsheet) NewRegistry() *Registry {
+	return &Registry{
+		NameFunc: func(e *CatalogEntry) string { return e.Name },
+		is:       is,
+		entries:  map[string]*CatalogEntry{},
+	}
+}
+
+// Scan walks folderId recursively and (re)builds the catalog with every
+// spreadsheet's id, tabs, header rows and last modified time. Spreadsheets
+// that can not be read are still cataloged, without tabs or headers, and
+// reported together in the returned error.
+func (r *Registry) Scan(folderId string) error {
+	srv, err := r.is.driveService()
+	if err != nil {
+		return err
+	}
+	found := []*CatalogEntry{}
+	if err = r.walk(srv, folderId, "", &found); err != nil {
+		return err
+	}
+	ids := []string{}
+	for _, e := range found {
+		ids = append(ids, e.SpreadsheetId)
+	}
+	errs := []error{}
+	infos, err := r.is.DescribeSpreadsheets(ids)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	entries := map[string]*CatalogEntry{}
+	for _, e := range found {
+		if info, ok := infos[e.SpreadsheetId]; ok {
+			for _, sh := range info.Sheets {
+				e.Tabs = append(e.Tabs, sh.Title)
+			}
+			if e.Headers, err = r.is.readHeaders(e.SpreadsheetId, e.Tabs); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.SpreadsheetId, err))
+			}
+		}
+		name := r.NameFunc(e)
+		if old, ok := entries[name]; ok && old.ModifiedTime.After(e.ModifiedTime) {
+			continue
+		}
+		e.Name = name
+		entries[name] = e
+	}
+	r.mutex.Lock()
+	r.entries = entries
+	r.mutex.Unlock()
+	return errors.Join(errs...)
+}
+
+func (r *Registry) walk(srv *drive.Service, folderId, dir string, found *[]*CatalogEntry) error {
+	q := fmt.Sprintf("'%s' in parents and trashed = false and (mimeType = '%s' or mimeType = '%s')",
+		folderId, spreadsheetMimeType, folderMimeType)
+	return srv.Files.List().Q(q).
+		Fields("nextPageToken, files(id, name, mimeType, modifiedTime)").
+		SupportsAllDrives(true).IncludeItemsFromAllDrives(true).
+		Pages(r.is.ctx, func(list *drive.FileList) error {
+			for _, f := range list.Files {
+				if f.MimeType == folderMimeType {
+					if err := r.walk(srv, f.Id, path.Join(dir, f.Name), found); err != nil {
+						return err
+					}
+					continue
+				}
+				modified, _ := time.Parse(time.RFC3339, f.ModifiedTime)
+				*found = append(*found, &CatalogEntry{
+					Name:          f.Name,
+					SpreadsheetId: f.Id,
+					Path:          dir,
+					ModifiedTime:  modified,
+				})
+			}
+			return nil
+		})
+}
+
+// readHeaders returns the first row of every tab in one batchGet.
+func (is *Gsheet) readHeaders(spreadsheetId string, tabs []string) (map[string][]string, error) {
+	headers := map[string][]string{}
+	if len(tabs) == 0 {
+		return headers, nil
+	}
+	ranges := []string{}
+	for _, tab := range tabs {
+		ranges = append(ranges, RowsRange(tab, 0, 0).String())
+	}
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	defer is.rlock(spreadsheetId)()
+	var resp *sheets.BatchGetValuesResponse
+	err := is.retry(true, func() (err error) {
+		resp, err = is.Spreadsheets.Values.BatchGet(spreadsheetId).Ranges(ranges...).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, vr := range resp.ValueRanges {
+		header := []string{}
+		if len(vr.Values) != 0 {
+			for _, v := range vr.Values[0] {
+				header = append(header, fmt.Sprint(v))
+			}
+		}
+		headers[tabs[i]] = header
+	}
+	return headers, nil
+}
+
+// Lookup returns the entry registered under a logical name.
+func (r *Registry) Lookup(name string) (*CatalogEntry, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	e, ok := r.entries[name]
+	return e, ok
+}
+
+// Entries returns all entries sorted by logical name.
+func (r *Registry) Entries() []*CatalogEntry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	ret := make([]*CatalogEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
+
+var catalogHeader = []interface{}{"Name", "SpreadsheetId", "Path", "ModifiedTime", "Tabs", "Headers"}
+
+// SaveTo writes the catalog into sheetName of a master spreadsheet, replacing its content.
+func (r *Registry) SaveTo(sheetName string, sprids ...string) error {
+	rows := [][]interface{}{catalogHeader}
+	for _, e := range r.Entries() {
+		headers, err := json.Marshal(e.Headers)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []interface{}{
+			e.Name, e.SpreadsheetId, e.Path, e.ModifiedTime.Format(time.RFC3339),
+			strings.Join(e.Tabs, "\n"), string(headers),
+		})
+	}
+	if err := r.is.ClearRange(NewRange(sheetName).String(), sprids...); err != nil {
+		return err
+	}
+	// raw so names like "2024-01" or "=x" read back as written
+	return r.is.UpdateRangeWith(rows, CellRange(sheetName, 0, 0).String(), WriteOptions{ValueInputOption: Raw}, sprids...)
+}
+
+// LoadFrom replaces the catalog with one previously stored by SaveTo.
+func (r *Registry) LoadFrom(sheetName string, sprids ...string) error {
+	rows, err := r.is.GetValueRange(NewRange(sheetName).String(), sprids...)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		rows = [][]string{{}}
+	}
+	entries := map[string]*CatalogEntry{}
+	for _, row := range rows[1:] {
+		for len(row) < len(catalogHeader) {
+			row = append(row, "")
+		}
+		e := &CatalogEntry{Name: row[0], SpreadsheetId: row[1], Path: row[2], Headers: map[string][]string{}}
+		e.ModifiedTime, _ = time.Parse(time.RFC3339, row[3])
+		if len(row[4]) != 0 {
+			e.Tabs = strings.Split(row[4], "\n")
+		}
+		if len(row[5]) != 0 {
+			if err = json.Unmarshal([]byte(row[5]), &e.Headers); err != nil {
+				return fmt.Errorf("catalog row %s: %w", e.Name, err)
+			}
+		}
+		entries[e.Name] = e
+	}
+	r.mutex.Lock()
+	r.entries = entries
+	r.mutex.Unlock()
+	return nil
+}
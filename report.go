@@ -0,0 +1,119 @@
+package gogsheet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Severities of a ValidationResult.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// ValidationResult is one failed schema or integrity check.
+type ValidationResult struct {
+	Range    string // offending cell or range in A1 notation, with sheet name
+	Rule     string // name of the check, e.g. "required" or "unique"
+	Message  string
+	Severity string // SeverityError when empty
+}
+
+var severityColors = map[string]*sheets.Color{
+	SeverityError:   {Red: 0.96, Green: 0.8, Blue: 0.8},
+	SeverityWarning: {Red: 1, Green: 0.95, Blue: 0.7},
+	SeverityInfo:    {Red: 0.81, Green: 0.89, Blue: 0.95},
+}
+
+var severityOrder = map[string]int{SeverityError: 0, SeverityWarning: 1, SeverityInfo: 2}
+
+// WriteValidationReport replaces the content of targetSheet, created when
+// missing, with one row per result, errors first: severity, rule, message and
+// a link to the offending cells. Rows are colored by severity.
+func (is *Gsheet) WriteValidationReport(targetSheet string, results []ValidationResult, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	ids, err := is.ListSheets(spreadsheetId)
+	if err != nil {
+		return err
+	}
+	reportId, ok := ids[targetSheet]
+	if !ok {
+		if reportId, err = is.CreaateSheet(targetSheet, spreadsheetId); err != nil {
+			return err
+		}
+	} else if err = is.ClearRange(NewRange(targetSheet).String(), spreadsheetId); err != nil {
+		return err
+	}
+	results = append([]ValidationResult(nil), results...)
+	for i := range results {
+		if len(results[i].Severity) == 0 {
+			results[i].Severity = SeverityError
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return severityOrder[results[i].Severity] < severityOrder[results[j].Severity]
+	})
+	rows := [][]interface{}{{"Severity", "Rule", "Message", "Cell"}}
+	for _, res := range results {
+		rows = append(rows, []interface{}{res.Severity, res.Rule, "'" + res.Message, TrustedFormula(cellLink(ids, res.Range))})
+	}
+	if err = is.UpdateRangeWith(rows, CellRange(targetSheet, 0, 0).String(), WriteOptions{ValueInputOption: UserEntered}, spreadsheetId); err != nil {
+		return err
+	}
+	b := is.Batch(spreadsheetId).Raw(
+		&sheets.Request{RepeatCell: &sheets.RepeatCellRequest{
+			Range:  &sheets.GridRange{SheetId: reportId},
+			Cell:   &sheets.CellData{UserEnteredFormat: &sheets.CellFormat{}},
+			Fields: "userEnteredFormat(backgroundColor,textFormat.bold)",
+		}},
+		&sheets.Request{RepeatCell: &sheets.RepeatCellRequest{
+			Range:  &sheets.GridRange{SheetId: reportId, StartRowIndex: 0, EndRowIndex: 1},
+			Cell:   &sheets.CellData{UserEnteredFormat: &sheets.CellFormat{TextFormat: &sheets.TextFormat{Bold: true}}},
+			Fields: "userEnteredFormat.textFormat.bold",
+		}},
+		&sheets.Request{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: reportId, GridProperties: &sheets.GridProperties{FrozenRowCount: 1}},
+			Fields:     "gridProperties.frozenRowCount",
+		}},
+	)
+	for start := 0; start < len(results); {
+		end := start + 1
+		for end < len(results) && results[end].Severity == results[start].Severity {
+			end++
+		}
+		if color, ok := severityColors[results[start].Severity]; ok {
+			b.Raw(&sheets.Request{RepeatCell: &sheets.RepeatCellRequest{
+				Range:  &sheets.GridRange{SheetId: reportId, StartRowIndex: int64(start + 1), EndRowIndex: int64(end + 1), EndColumnIndex: 4},
+				Cell:   &sheets.CellData{UserEnteredFormat: &sheets.CellFormat{BackgroundColor: color}},
+				Fields: "userEnteredFormat.backgroundColor",
+			}})
+		}
+		start = end
+	}
+	_, err = b.Do()
+	return err
+}
+
+// cellLink returns a HYPERLINK formula jumping to rangeA1, or rangeA1 itself
+// when its sheet is unknown.
+func cellLink(ids map[string]int64, rangeA1 string) string {
+	r, err := ParseRange(rangeA1)
+	if err != nil {
+		return "'" + rangeA1
+	}
+	gid, ok := ids[r.Sheet]
+	if !ok {
+		return "'" + rangeA1
+	}
+	local := *r
+	local.Sheet = ""
+	quote := func(s string) string { return strings.ReplaceAll(s, `"`, `""`) }
+	return fmt.Sprintf(`=HYPERLINK("#gid=%d&range=%s","%s")`, gid, local.String(), quote(rangeA1))
+}
@@ -0,0 +1,136 @@
+package gogsheet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy decides how failed API calls are retried. Idempotent calls
+// (value Get/Update/Clear, metadata reads) are retried on transient errors.
+// Non-idempotent calls (Append, AddSheet, structural batch updates) are not,
+// because a timed out request may still have been applied. Appends can opt in
+// with RetryAppends when DedupeColumn names a token column kept empty for it:
+// every appended row gets a generated token there, and before each retry the
+// column is read back and the retry is skipped if the rows already landed.
+// Appends whose rows hold a value in that column are not retried.
+type RetryPolicy struct {
+	MaxAttempts    int // total attempts per call, 1 disables retries
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RetryAppends   bool
+	DedupeColumn   *int // zero-based column of the appended rows holding the dedupe token, nil for none
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     8 * time.Second,
+}
+
+func (is *Gsheet) SetRetryPolicy(policy RetryPolicy) {
+	is.retryPolicy = policy
+}
+
+// IsRetryable reports whether err is a transient failure worth retrying:
+// rate limiting, server errors and network timeouts.
+func IsRetryable(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusTooManyRequests || gerr.Code >= 500
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr) && nerr.Timeout()
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff << attempt
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// retry runs fn, retrying transient failures when the call is idempotent.
+func (is *Gsheet) retry(idempotent bool, fn func() error) error {
+	if !idempotent {
+		end, err := is.begin()
+		if err != nil {
+			return err
+		}
+		defer end()
+		return fn()
+	}
+	return is.retryGuarded(nil, "", nil, fn)
+}
+
+// retryLocked is retry of an idempotent call holding lock, e.g. is.wlock, on
+// spreadsheetId for each attempt but not during the backoff.
+func (is *Gsheet) retryLocked(lock func(spreadsheetId string) func(), spreadsheetId string, fn func() error) error {
+	return is.retryGuarded(lock, spreadsheetId, nil, fn)
+}
+
+// retryGuarded retries fn; when done is set it is consulted before every retry
+// and a true result means the previous attempt was applied after all. A lock
+// is held around each attempt with its done check and released while
+// sleeping, so other calls on the spreadsheet are not stalled by the backoff.
+func (is *Gsheet) retryGuarded(lock func(spreadsheetId string) func(), spreadsheetId string, done func() (bool, error), fn func() error) (err error) {
+	end, err := is.begin()
+	if err != nil {
+		return err
+	}
+	defer end()
+	policy := is.retryPolicy
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(policy.backoff(attempt - 1))
+		}
+		err = func() error {
+			if lock != nil {
+				defer lock(spreadsheetId)()
+			}
+			if attempt > 0 && done != nil {
+				if ok, derr := done(); derr == nil && ok {
+					return nil
+				}
+			}
+			return fn()
+		}()
+		if err == nil || attempt+1 >= policy.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+	}
+}
+
+func newDedupeToken() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// withDedupeTokens returns a copy of rows where every row carries a
+// generated token in column col. It fails when a row already holds a value
+// there, which could be mistaken for a landed token.
+func withDedupeTokens(rows [][]interface{}, col int) (ret [][]interface{}, tokens []string, err error) {
+	base := newDedupeToken()
+	for i, row := range rows {
+		r := make([]interface{}, len(row), max(len(row), col+1))
+		copy(r, row)
+		for len(r) <= col {
+			r = append(r, "")
+		}
+		if r[col] != nil && len(fmt.Sprint(r[col])) != 0 {
+			return nil, nil, fmt.Errorf("row %d holds %v in dedupe column %d", i, r[col], col)
+		}
+		r[col] = fmt.Sprintf("%s-%d", base, i)
+		ret = append(ret, r)
+		tokens = append(tokens, fmt.Sprint(r[col]))
+	}
+	return ret, tokens, nil
+}
@@ -0,0 +1,54 @@
+package gogsheet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestWithDedupeTokens(t *testing.T) {
+	rows, tokens, err := withDedupeTokens([][]interface{}{{"", "a"}, {nil, "b"}, {}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 3 || tokens[0] == tokens[1] {
+		t.Fatalf("tokens %v", tokens)
+	}
+	for i, row := range rows {
+		if row[0] != tokens[i] {
+			t.Errorf("row %d carries %v, want %s", i, row[0], tokens[i])
+		}
+	}
+	if _, _, err = withDedupeTokens([][]interface{}{{"", "a"}, {"1", "b"}}, 0); err == nil {
+		t.Error("a user value in the dedupe column was taken as token")
+	}
+}
+
+func TestRetryGuardedUnlocksDuringBackoff(t *testing.T) {
+	is := &Gsheet{retryPolicy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}
+	events := []string{}
+	lock := func(string) func() {
+		events = append(events, "lock")
+		return func() { events = append(events, "unlock") }
+	}
+	attempts := 0
+	err := is.retryGuarded(lock, "id", func() (bool, error) {
+		events = append(events, "done")
+		return false, nil
+	}, func() error {
+		events = append(events, "call")
+		if attempts++; attempts < 2 {
+			return &googleapi.Error{Code: 503}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "lock call unlock lock done call unlock"
+	if got := strings.Join(events, " "); got != want {
+		t.Errorf("events %q, want %q", got, want)
+	}
+}
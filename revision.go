@@ -0,0 +1,93 @@
+package gogsheet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/api/drive/v3"
+)
+
+// Revision returns a token that changes whenever the spreadsheet is edited:
+// the Drive file version and modifiedTime. It costs one small Drive call, far
+// less than reading a big range, and needs the drive.DriveMetadataReadonlyScope
+// or a wider Drive scope.
+func (is *Gsheet) Revision(sprids ...string) (string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if err := is.readable(); err != nil {
+		return "", err
+	}
+	srv, err := is.driveService()
+	if err != nil {
+		return "", err
+	}
+	var f *drive.File
+	err = is.retry(true, func() (err error) {
+		f, err = srv.Files.Get(spreadsheetId).Fields("version, modifiedTime").SupportsAllDrives(true).Do()
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d@%s", f.Version, f.ModifiedTime), nil
+}
+
+// RangeSync keeps the last read of a range and re-reads it only when the
+// spreadsheet changed, for pollers that would otherwise download everything
+// on every tick.
+type RangeSync struct {
+	is            *Gsheet
+	mutex         sync.Mutex
+	spreadsheetId string
+	readRange     string
+	revision      string
+	rows          [][]string
+}
+
+// NewRangeSync returns a RangeSync on readRange; the first SyncIfChanged
+// always reads.
+func (is *Gsheet) NewRangeSync(readRange string, sprids ...string) *RangeSync {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return &RangeSync{is: is, spreadsheetId: spreadsheetId, readRange: readRange}
+}
+
+// SyncIfChanged checks the spreadsheet revision and returns the rows kept
+// from the previous sync with changed false when it did not move; otherwise it
+// reads the range again. Any edit of the spreadsheet counts as a change, not
+// only edits of the range.
+func (s *RangeSync) SyncIfChanged() (rows [][]string, changed bool, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	revision, err := s.is.Revision(s.spreadsheetId)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.rows != nil && revision == s.revision {
+		return copyRows(s.rows), false, nil
+	}
+	// the revision is taken before the read, so an edit landing in between
+	// only causes one extra read on the next sync
+	s.is.invalidate(s.spreadsheetId, s.readRange)
+	rows, err = s.is.GetValueRange(s.readRange, s.spreadsheetId)
+	if err != nil && !errors.Is(err, ErrNoData) {
+		return nil, false, err
+	}
+	if rows == nil {
+		rows = [][]string{}
+	}
+	s.revision, s.rows = revision, rows
+	return copyRows(rows), true, nil
+}
+
+// Revision returns the revision of the last sync, empty before the first.
+func (s *RangeSync) Revision() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.revision
+}
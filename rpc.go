@@ -0,0 +1,162 @@
+package gogsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RPCOptions configures RPCHandler.
+type RPCOptions struct {
+	// Authorize is called with the method name and decoded params of every
+	// call, params.SpreadsheetId set to the spreadsheet the call goes to; a
+	// non nil error rejects the call. Nil allows every call.
+	Authorize func(r *http.Request, method string, params *RPCParams) error
+	// RateLimit limits calls per second over all clients, 0 is unlimited.
+	RateLimit float64
+	Burst     int
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// RPCParams are the params of every RPC method; each method uses the fields it needs.
+type RPCParams struct {
+	SpreadsheetId string          `json:"spreadsheetId"`
+	Range         string          `json:"range"`
+	Ranges        []string        `json:"ranges"`
+	Rows          [][]interface{} `json:"rows"`
+	Sheet         string          `json:"sheet"`
+	SheetId       int64           `json:"sheetId"`
+}
+
+// JSON-RPC error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+	rpcForbidden      = -32001
+	rpcRateLimited    = -32002
+)
+
+var rpcMethods = map[string]func(is *Gsheet, p *RPCParams) (interface{}, error){
+	"GetValueRange": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return is.GetValueRange(p.Range, p.SpreadsheetId)
+	},
+	"GetValueRanges": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return is.GetValueRanges(p.Ranges, p.SpreadsheetId)
+	},
+	"UpdateRange": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return nil, is.UpdateRange(p.Rows, p.Range, p.SpreadsheetId)
+	},
+	"AppendRows": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return is.AppendRows(p.Rows, p.Range, p.SpreadsheetId)
+	},
+	"ClearRange": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return nil, is.ClearRange(p.Range, p.SpreadsheetId)
+	},
+	"ListSheets": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return is.ListSheets(p.SpreadsheetId)
+	},
+	"CreateSheet": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return is.CreaateSheet(p.Sheet, p.SpreadsheetId)
+	},
+	"DeleteSheet": func(is *Gsheet, p *RPCParams) (interface{}, error) {
+		return nil, is.DeleteSheetId(p.SheetId, p.SpreadsheetId)
+	},
+}
+
+// RPCMethods returns the names of the methods served by RPCHandler.
+func RPCMethods() []string {
+	names := []string{}
+	for name := range rpcMethods {
+		names = append(names, name)
+	}
+	return names
+}
+
+// RPCHandler serves the client's operations as JSON-RPC 2.0 over HTTP POST, so
+// non Go services can share one credentialed, rate limited gateway instead of
+// each holding Google credentials. Params are RPCParams, an empty
+// spreadsheetId selects the client's default spreadsheet.
+func (is *Gsheet) RPCHandler(opts RPCOptions) http.Handler {
+	var limiter *tokenBucket
+	if opts.RateLimit > 0 {
+		limiter = newTokenBucket(opts.RateLimit, max(opts.Burst, 1))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req rpcRequest
+		resp := rpcResponse{JSONRPC: "2.0"}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			resp.Error = &rpcError{rpcParseError, err.Error()}
+			writeJSON(w, resp)
+			return
+		}
+		resp.ID = req.ID
+		resp.Result, resp.Error = is.callRPC(r, &req, opts, limiter)
+		writeJSON(w, resp)
+	})
+}
+
+func (is *Gsheet) callRPC(r *http.Request, req *rpcRequest, opts RPCOptions, limiter *tokenBucket) (interface{}, *rpcError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{rpcInvalidRequest, "jsonrpc must be \"2.0\""}
+	}
+	method, ok := rpcMethods[req.Method]
+	if !ok {
+		return nil, &rpcError{rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+	}
+	params := new(RPCParams)
+	if len(req.Params) != 0 {
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil, &rpcError{rpcInvalidParams, err.Error()}
+		}
+	}
+	if len(params.SpreadsheetId) == 0 {
+		params.SpreadsheetId = is.spreadsheetId
+	}
+	if opts.Authorize != nil {
+		if err := opts.Authorize(r, req.Method, params); err != nil {
+			return nil, &rpcError{rpcForbidden, err.Error()}
+		}
+	}
+	if limiter != nil && !limiter.allow() {
+		return nil, &rpcError{rpcRateLimited, "rate limit exceeded"}
+	}
+	result, err := method(is, params)
+	if err != nil {
+		code := rpcServerError
+		if errors.Is(err, ErrPolicy) {
+			code = rpcForbidden
+		}
+		return nil, &rpcError{code, err.Error()}
+	}
+	if result == nil {
+		result = true
+	}
+	return result, nil
+}
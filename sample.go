@@ -0,0 +1,227 @@
+package gogsheet
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SampleOptions configures SampleRows. Columns are zero-based within the range.
+type SampleOptions struct {
+	StratifyColumn int  // sample each value of this column proportionally, -1 for none
+	WeightColumn   int  // numeric weights, -1 for uniform sampling
+	Header         bool // the first row of the range is a header, never sampled
+	Rand           *rand.Rand
+}
+
+// DefaultSampleOptions is uniform sampling without header.
+var DefaultSampleOptions = SampleOptions{StratifyColumn: -1, WeightColumn: -1}
+
+// SampledRow is a row picked by SampleRows.
+type SampledRow struct {
+	Index int // zero-based sheet row
+	Row   []string
+}
+
+// sampleBatch bounds the ranges of one batchGet while fetching samples.
+const sampleBatch = 200
+
+// SampleRows picks up to n rows of readRange at random, without replacement.
+// With StratifyColumn every value gets one row when n allows it and the rest
+// is allocated in proportion to the size of each stratum.
+// Only the stratify, weight or first column is read in full; the picked rows
+// are then fetched with batchGet. Rows whose probe column is empty are not
+// candidates. Rows come back in sheet order.
+func (is *Gsheet) SampleRows(readRange string, n int, opts SampleOptions, sprids ...string) ([]SampledRow, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	r, err := ParseRange(readRange)
+	if err != nil {
+		return nil, err
+	}
+	rnd := opts.Rand
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	startCol, startRow := max(r.StartCol, 0), max(r.StartRow, 0)
+	if opts.Header {
+		startRow++
+	}
+	probe := func(col int) (*Range, error) {
+		if r.EndCol >= 0 && startCol+col > r.EndCol {
+			return nil, fmt.Errorf("column %d is outside %s", col, readRange)
+		}
+		return &Range{Sheet: r.Sheet, StartCol: startCol + col, StartRow: startRow, EndCol: startCol + col, EndRow: r.EndRow}, nil
+	}
+	cols := []int{0}
+	if opts.StratifyColumn >= 0 || opts.WeightColumn >= 0 {
+		cols = cols[:0]
+		for _, c := range []int{opts.StratifyColumn, opts.WeightColumn} {
+			if c >= 0 {
+				cols = append(cols, c)
+			}
+		}
+	}
+	ranges := []string{}
+	for _, c := range cols {
+		pr, err := probe(c)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, pr.String())
+	}
+	valueRanges, err := is.batchGetValues(spreadsheetId, ranges, ReadOptions{ValueRenderOption: UnformattedValue, MajorDimension: DimensionColumns})
+	if err != nil {
+		return nil, err
+	}
+	column := func(i int) []string {
+		if i < len(valueRanges) && len(valueRanges[i].Values) != 0 {
+			return stringRows(valueRanges[i].Values)[0]
+		}
+		return nil
+	}
+	var strata, weights []string
+	switch {
+	case opts.StratifyColumn >= 0 && opts.WeightColumn >= 0:
+		strata, weights = column(0), column(1)
+	case opts.StratifyColumn >= 0:
+		strata = column(0)
+	case opts.WeightColumn >= 0:
+		weights = column(0)
+	default:
+		strata = column(0)
+	}
+	probeCol := strata
+	if probeCol == nil {
+		probeCol = weights
+	}
+	groups := map[string][]int{}
+	total := 0
+	for i, v := range probeCol {
+		if isBlank(v) {
+			continue
+		}
+		key := ""
+		if opts.StratifyColumn >= 0 {
+			key = strings.TrimSpace(v)
+		}
+		groups[key] = append(groups[key], i)
+		total++
+	}
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sizes := make([]int, len(keys))
+	for i, k := range keys {
+		sizes[i] = len(groups[k])
+	}
+	picked := []int{}
+	for i, quota := range allocate(sizes, n) {
+		picked = append(picked, pickWeighted(rnd, groups[keys[i]], weights, quota)...)
+	}
+	sort.Ints(picked)
+	return is.fetchRows(spreadsheetId, r, startRow, picked)
+}
+
+// allocate splits n among strata of the given sizes by largest remainder so
+// the quotas sum to min(n, total). Every stratum gets one row first when n
+// allows it; the rest is shared in proportion to the rows left.
+func allocate(sizes []int, n int) []int {
+	quotas := make([]int, len(sizes))
+	rest := append([]int(nil), sizes...)
+	total := 0
+	for _, size := range sizes {
+		total += size
+	}
+	n = min(n, total)
+	if n >= len(sizes) {
+		for i := range quotas {
+			quotas[i], rest[i] = 1, rest[i]-1
+		}
+		n, total = n-len(sizes), total-len(sizes)
+	}
+	if n <= 0 {
+		return quotas
+	}
+	remainders := make([]int, len(sizes))
+	given := 0
+	for i, size := range rest {
+		share := n * size
+		quotas[i] += share / total
+		remainders[i] = share % total
+		given += share / total
+	}
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order[:n-given] {
+		quotas[i]++
+	}
+	return quotas
+}
+
+// pickWeighted draws k of members without replacement, weighted by
+// weights[member] when given (Efraimidis-Spirakis keys), uniformly otherwise.
+func pickWeighted(rnd *rand.Rand, members []int, weights []string, k int) []int {
+	type keyed struct {
+		i   int
+		key float64
+	}
+	ks := make([]keyed, 0, len(members))
+	for _, m := range members {
+		w := 1.0
+		if weights != nil {
+			w = 0
+			if m < len(weights) {
+				w, _ = strconv.ParseFloat(strings.TrimSpace(weights[m]), 64)
+			}
+			if w <= 0 {
+				continue
+			}
+		}
+		ks = append(ks, keyed{m, math.Pow(rnd.Float64(), 1/w)})
+	}
+	sort.Slice(ks, func(i, j int) bool { return ks[i].key > ks[j].key })
+	ret := []int{}
+	for _, kv := range ks[:min(k, len(ks))] {
+		ret = append(ret, kv.i)
+	}
+	return ret
+}
+
+// fetchRows reads the rows at offsets of r starting at startRow with batchGet.
+func (is *Gsheet) fetchRows(spreadsheetId string, r *Range, startRow int, offsets []int) ([]SampledRow, error) {
+	ret := make([]SampledRow, 0, len(offsets))
+	for len(offsets) != 0 {
+		part := offsets[:min(sampleBatch, len(offsets))]
+		offsets = offsets[len(part):]
+		ranges := make([]string, len(part))
+		for i, off := range part {
+			row := *r
+			row.StartRow, row.EndRow = startRow+off, startRow+off
+			ranges[i] = row.String()
+		}
+		valueRanges, err := is.batchGetValues(spreadsheetId, ranges, is.readOptions)
+		if err != nil {
+			return nil, err
+		}
+		for i, off := range part {
+			sr := SampledRow{Index: startRow + off, Row: []string{}}
+			if i < len(valueRanges) && len(valueRanges[i].Values) != 0 {
+				sr.Row = stringRows(valueRanges[i].Values)[0]
+			}
+			ret = append(ret, sr)
+		}
+	}
+	return ret, nil
+}
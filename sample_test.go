@@ -0,0 +1,27 @@
+package gogsheet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		sizes []int
+		n     int
+		want  []int
+	}{
+		{[]int{90, 5, 5}, 10, []int{8, 1, 1}},
+		{[]int{1, 1, 1, 97}, 4, []int{1, 1, 1, 1}},
+		{[]int{34, 33, 33}, 10, []int{4, 3, 3}},
+		{[]int{60, 30, 10}, 2, []int{1, 1, 0}},
+		{[]int{3, 2}, 10, []int{3, 2}},
+		{[]int{5}, 3, []int{3}},
+	}
+	for _, tt := range tests {
+		got := allocate(tt.sizes, tt.n)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("allocate(%v, %d) = %v, want %v", tt.sizes, tt.n, got, tt.want)
+		}
+	}
+}
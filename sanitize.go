@@ -0,0 +1,63 @@
+package gogsheet
+
+import "strings"
+
+// TrustedFormula marks a cell value the application built itself, e.g.
+// "=SUM(A:A)"; it is written as a formula even when sanitizing.
+type TrustedFormula string
+
+// SetSanitizeFormulas makes UpdateRange, UpdateRanges and AppendRows prefix
+// string cells starting with '=', '+', '-' or '@' with an apostrophe, so
+// untrusted input is stored as text instead of running as a formula.
+// Numbers, booleans and TrustedFormula values are left alone.
+func (is *Gsheet) SetSanitizeFormulas(on bool) {
+	is.sanitize = on
+}
+
+// SanitizeCell returns s escaped as SetSanitizeFormulas does.
+func SanitizeCell(s string) string {
+	if len(s) != 0 && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// prepareRows returns rows as they are sent to the API: a sanitized copy
+// when sanitizing, with TrustedFormula values turned into plain strings.
+// RAW writes never run formulas and are not sanitized.
+func (is *Gsheet) prepareRows(rows [][]interface{}, opts WriteOptions) [][]interface{} {
+	sanitize := is.sanitize && opts.valueInputOption() != Raw
+	changed := false
+	for _, row := range rows {
+		for _, v := range row {
+			switch v := v.(type) {
+			case TrustedFormula:
+				changed = true
+			case string:
+				changed = changed || sanitize && SanitizeCell(v) != v
+			}
+		}
+	}
+	if !changed {
+		return rows
+	}
+	ret := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		ret[i] = make([]interface{}, len(row))
+		for j, v := range row {
+			switch v := v.(type) {
+			case TrustedFormula:
+				ret[i][j] = string(v)
+			case string:
+				if sanitize {
+					ret[i][j] = SanitizeCell(v)
+				} else {
+					ret[i][j] = v
+				}
+			default:
+				ret[i][j] = v
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,244 @@
+package gogsheet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule is one row of a schedules sheet. The sheet has a header row with
+// the columns Name (or Job), Cron (or Schedule), Params (or Parameters) and an
+// optional Enabled column; other columns are ignored.
+type Schedule struct {
+	Name    string
+	Cron    string
+	Params  map[string]string // "key=value; key2=value2" or a JSON object
+	Enabled bool              // false when the Enabled cell is FALSE/no/0
+	Row     int               // zero-based sheet row, for error messages and links
+}
+
+var cronDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// ValidateCron checks the shape of a cron expression: 5 fields, 6 with
+// seconds, a descriptor such as "@daily" or "@every 5m".
+func ValidateCron(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every ") {
+		_, err := time.ParseDuration(strings.TrimSpace(expr[len("@every "):]))
+		return err
+	}
+	if cronDescriptors[expr] {
+		return nil
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 && len(fields) != 6 {
+		return fmt.Errorf("cron %q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+	for _, f := range fields {
+		for _, c := range strings.ToUpper(f) {
+			if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || strings.ContainsRune("*/,-?LW#", c)) {
+				return fmt.Errorf("cron %q: invalid character %q", expr, c)
+			}
+		}
+	}
+	return nil
+}
+
+func headerIndex(header []string, names ...string) int {
+	for i, h := range header {
+		for _, n := range names {
+			if strings.EqualFold(strings.TrimSpace(h), n) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseParams(s string) (map[string]string, error) {
+	params := map[string]string{}
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return params, nil
+	}
+	if strings.HasPrefix(s, "{") {
+		raw := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(s), &raw); err != nil {
+			return nil, err
+		}
+		for k, v := range raw {
+			params[k] = fmt.Sprint(v)
+		}
+		return params, nil
+	}
+	for _, kv := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("param %q is not key=value", kv)
+		}
+		params[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return params, nil
+}
+
+// ReadSchedules reads the schedules of sheetName. Invalid rows are skipped
+// and reported together in the returned error, next to the valid schedules.
+// An empty sheet has no schedules; a failed read returns nil.
+func (is *Gsheet) ReadSchedules(sheetName string, sprids ...string) ([]Schedule, error) {
+	rows, err := is.GetValueRange(NewRange(sheetName).String(), sprids...)
+	if errors.Is(err, ErrNoData) {
+		return []Schedule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []Schedule{}, nil
+	}
+	name := headerIndex(rows[0], "Name", "Job")
+	cron := headerIndex(rows[0], "Cron", "Schedule")
+	params := headerIndex(rows[0], "Params", "Parameters")
+	enabled := headerIndex(rows[0], "Enabled")
+	if name < 0 || cron < 0 {
+		return nil, fmt.Errorf("sheet %s needs Name and Cron columns", sheetName)
+	}
+	cell := func(row []string, i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	schedules := []Schedule{}
+	errs := []error{}
+	for r, row := range rows[1:] {
+		sc := Schedule{Name: cell(row, name), Cron: cell(row, cron), Enabled: true, Row: r + 1}
+		if len(sc.Name) == 0 && len(sc.Cron) == 0 {
+			continue
+		}
+		if err = ValidateCron(sc.Cron); err != nil {
+			errs = append(errs, fmt.Errorf("row %d (%s): %w", sc.Row+1, sc.Name, err))
+			continue
+		}
+		if sc.Params, err = parseParams(cell(row, params)); err != nil {
+			errs = append(errs, fmt.Errorf("row %d (%s): %w", sc.Row+1, sc.Name, err))
+			continue
+		}
+		switch strings.ToLower(cell(row, enabled)) {
+		case "false", "no", "n", "0", "off":
+			sc.Enabled = false
+		}
+		schedules = append(schedules, sc)
+	}
+	return schedules, errors.Join(errs...)
+}
+
+func schedulesFingerprint(schedules []Schedule) string {
+	lines := []string{}
+	for _, sc := range schedules {
+		keys := []string{}
+		for k := range sc.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		line := fmt.Sprintf("%s|%s|%v", sc.Name, sc.Cron, sc.Enabled)
+		for _, k := range keys {
+			line += "|" + k + "=" + sc.Params[k]
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(lines, "\n"))))
+}
+
+// ScheduleWatcher polls a schedules sheet and reports changes.
+type ScheduleWatcher struct {
+	*poller
+	mutex     sync.Mutex
+	schedules []Schedule
+}
+
+// WatchSchedules reads sheetName every interval and calls onChange with the
+// full list whenever it differs from the previous one, including the first
+// read. Read errors are passed to onError when set and keep the last list.
+// Close stops the watcher.
+func (is *Gsheet) WatchSchedules(sheetName string, interval time.Duration, onChange func([]Schedule), onError func(error), sprids ...string) (*ScheduleWatcher, error) {
+	w := &ScheduleWatcher{}
+	last := ""
+	var err error
+	w.poller, err = is.startPoller(interval, func() {
+		schedules, err := is.ReadSchedules(sheetName, sprids...)
+		if err != nil && onError != nil {
+			onError(err)
+		}
+		if schedules != nil {
+			if fp := schedulesFingerprint(schedules); fp != last {
+				last = fp
+				w.mutex.Lock()
+				w.schedules = schedules
+				w.mutex.Unlock()
+				onChange(schedules)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Schedules returns the last list read.
+func (w *ScheduleWatcher) Schedules() []Schedule {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.schedules
+}
+
+// poller runs a function now and then every interval until stopped or the
+// client is closed. The interval must be positive.
+type poller struct {
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	remove   func()
+}
+
+func (is *Gsheet) startPoller(interval time.Duration, fn func()) (*poller, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid poll interval %v", interval)
+	}
+	p := &poller{stop: make(chan struct{}), done: make(chan struct{})}
+	p.remove = is.onClose(func(ctx context.Context) error {
+		p.Stop()
+		return nil
+	})
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn()
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return p, nil
+}
+
+// Stop ends polling and waits for the poller to exit.
+func (p *poller) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	<-p.done
+	p.remove()
+}
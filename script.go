@@ -0,0 +1,82 @@
+package gogsheet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/api/script/v1"
+)
+
+// ScriptError is returned by RunScript when the script function throws.
+type ScriptError struct {
+	Type    string
+	Message string
+	Stack   []string // "function:line" from innermost to outermost
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("apps script %s: %s", e.Type, e.Message)
+}
+
+// scriptService returns the Apps Script API client sharing the sheets credentials.
+func (is *Gsheet) scriptService() (*script.Service, error) {
+	is.mutex.Lock()
+	defer is.mutex.Unlock()
+	if is.script == nil {
+		srv, err := script.NewService(is.ctx, is.clientOption)
+		if err != nil {
+			return nil, err
+		}
+		is.script = srv
+	}
+	return is.script, nil
+}
+
+// RunScript runs function of the Apps Script project scriptID through the
+// Apps Script Execution API and returns its return value. The script must be
+// deployed as an API executable and the client authorized (oauth2 token) with
+// the scopes the script uses; service accounts are not supported by the API.
+// Set devMode to run the latest saved code instead of the deployed version.
+// Scripts are not run while building requests, they fail with ErrBuildOnly.
+func (is *Gsheet) RunScript(scriptID, function string, params []interface{}, devMode ...bool) (interface{}, error) {
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	srv, err := is.scriptService()
+	if err != nil {
+		return nil, err
+	}
+	rq := &script.ExecutionRequest{Function: function, Parameters: params}
+	if len(devMode) != 0 {
+		rq.DevMode = devMode[0]
+	}
+	// a script run may have side effects, so it is never retried
+	var op *script.Operation
+	err = is.retry(false, func() (err error) {
+		op, err = srv.Scripts.Run(scriptID, rq).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if op.Error != nil {
+		serr := &ScriptError{Type: "Error", Message: op.Error.Message}
+		for _, d := range op.Error.Details {
+			var ee script.ExecutionError
+			if json.Unmarshal(d, &ee) == nil && len(ee.ErrorMessage) != 0 {
+				serr.Type, serr.Message = ee.ErrorType, ee.ErrorMessage
+				for _, st := range ee.ScriptStackTraceElements {
+					serr.Stack = append(serr.Stack, fmt.Sprintf("%s:%d", st.Function, st.LineNumber))
+				}
+			}
+		}
+		return nil, serr
+	}
+	var resp script.ExecutionResponse
+	if len(op.Response) != 0 {
+		if err = json.Unmarshal(op.Response, &resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp.Result, nil
+}
@@ -0,0 +1,129 @@
+package gogsheet
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Session serves many sheet id and cell lookups on one spreadsheet from
+// cached data: sheet metadata is read once and cells requested together are
+// fetched with a single batchGet. Values are not refreshed until Refresh.
+//
+//	s := g.NewSession()
+//	s.Prefetch("Config!B2", "Config!B3", "Rates!C7")
+//	v, err := s.Cell("Config", "B2") // no API call
+type Session struct {
+	is            *Gsheet
+	mutex         sync.Mutex
+	spreadsheetId string
+	sheetIds      map[string]int64
+	cells         map[string]string
+}
+
+func (is *Gsheet) NewSession(sprids ...string) *Session {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return &Session{is: is, spreadsheetId: spreadsheetId, cells: map[string]string{}}
+}
+
+// SheetId returns the id of sheetName, listing the sheets on first use only.
+func (s *Session) SheetId(sheetName string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.sheetIds == nil {
+		ids, err := s.is.ListSheets(s.spreadsheetId)
+		if err != nil {
+			return 0, err
+		}
+		s.sheetIds = ids
+	}
+	id, ok := s.sheetIds[sheetName]
+	if !ok {
+		return 0, fmt.Errorf("sheet %s not found", sheetName)
+	}
+	return id, nil
+}
+
+// cellKey normalizes a single cell reference such as "'My Sheet'!$B$2".
+func cellKey(ref string) (string, error) {
+	r, err := ParseRange(ref)
+	if err != nil {
+		return "", err
+	}
+	if r.StartCol < 0 || r.StartRow < 0 || r.EndCol != r.StartCol || r.EndRow != r.StartRow {
+		return "", fmt.Errorf("%s is not a single cell", ref)
+	}
+	return r.String(), nil
+}
+
+// Prefetch loads the cells not cached yet with one batchGet.
+func (s *Session) Prefetch(refs ...string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	missing := []string{}
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		key, err := cellKey(ref)
+		if err != nil {
+			return err
+		}
+		if _, ok := s.cells[key]; !ok && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	valueRanges, err := s.is.batchGetValues(s.spreadsheetId, missing, s.is.readOptions)
+	if err != nil {
+		return err
+	}
+	for i, key := range missing {
+		v := ""
+		if i < len(valueRanges) && len(valueRanges[i].Values) != 0 && len(valueRanges[i].Values[0]) != 0 {
+			v = fmt.Sprint(valueRanges[i].Values[0][0])
+		}
+		s.cells[key] = v
+	}
+	return nil
+}
+
+// Cell returns the value of cellAddress in sheetname, "" when empty, fetching
+// it only when it was not prefetched.
+func (s *Session) Cell(sheetname, cellAddress string) (string, error) {
+	ref := QuoteSheetName(sheetname) + "!" + cellAddress
+	if err := s.Prefetch(ref); err != nil {
+		return "", err
+	}
+	key, _ := cellKey(ref)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cells[key], nil
+}
+
+// Cells returns the values of refs keyed by ref, with one batchGet for those
+// not cached yet.
+func (s *Session) Cells(refs ...string) (map[string]string, error) {
+	if err := s.Prefetch(refs...); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ret := map[string]string{}
+	for _, ref := range refs {
+		key, _ := cellKey(ref)
+		ret[ref] = s.cells[key]
+	}
+	return ret, nil
+}
+
+// Refresh drops cached sheet ids and cells.
+func (s *Session) Refresh() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sheetIds = nil
+	s.cells = map[string]string{}
+}
@@ -0,0 +1,165 @@
+package gogsheet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// SoftDeleteColumn is the header of the column holding the deletion time of
+// soft deleted rows; it is added after the last header when missing.
+var SoftDeleteColumn = "Deleted At"
+
+// softDeleteKey tags soft deleted rows with developer metadata.
+const softDeleteKey = "gogsheet.deleted"
+
+// softDeleteCol returns the column of SoftDeleteColumn in sheetName, adding
+// the header when create is set.
+func (is *Gsheet) softDeleteCol(spreadsheetId, sheetName string, create bool) (int, error) {
+	resp, err := is.getValues(spreadsheetId, RowsRange(sheetName, 0, 0).String(), ReadOptions{})
+	if err != nil {
+		return -1, err
+	}
+	header := []string{}
+	if len(resp.Values) != 0 {
+		header = stringRows(resp.Values)[0]
+	}
+	if col := headerIndex(header, SoftDeleteColumn); col >= 0 || !create {
+		return col, nil
+	}
+	col := len(header)
+	for col > 0 && isBlank(header[col-1]) {
+		col--
+	}
+	return col, is.UpdateRange([][]interface{}{{SoftDeleteColumn}}, CellRange(sheetName, col, 0).String(), spreadsheetId)
+}
+
+func hideRowsRequest(sheetid int64, row int64, hidden bool) *sheets.Request {
+	return &sheets.Request{UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+		Range:      &sheets.DimensionRange{SheetId: sheetid, Dimension: "ROWS", StartIndex: row, EndIndex: row + 1},
+		Properties: &sheets.DimensionProperties{HiddenByUser: hidden, ForceSendFields: []string{"HiddenByUser"}},
+		Fields:     "hiddenByUser",
+	}}
+}
+
+// SoftDeleteRows marks the zero-based rows of sheetName as deleted: the
+// current time goes to the SoftDeleteColumn, the rows are tagged with
+// developer metadata and hidden. RestoreRows undoes it and PurgeSoftDeleted
+// removes them for good.
+func (is *Gsheet) SoftDeleteRows(sheetName string, indices []int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, err := is.GetSheetIdFromNAme(sheetName, spreadsheetId)
+	if err != nil {
+		return err
+	}
+	for _, row := range indices {
+		if err = is.checkGrid(spreadsheetId, sheetid, RowsRange("", row, row)); err != nil {
+			return err
+		}
+	}
+	col, err := is.softDeleteCol(spreadsheetId, sheetName, true)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	rowsArray, ranges := [][][]interface{}{}, []string{}
+	b := is.Batch(spreadsheetId)
+	for _, row := range indices {
+		rowsArray = append(rowsArray, [][]interface{}{{"'" + now}})
+		ranges = append(ranges, CellRange(sheetName, col, row).String())
+		b.Raw(&sheets.Request{CreateDeveloperMetadata: &sheets.CreateDeveloperMetadataRequest{DeveloperMetadata: &sheets.DeveloperMetadata{
+			MetadataKey:   softDeleteKey,
+			MetadataValue: now,
+			Visibility:    "DOCUMENT",
+			Location: &sheets.DeveloperMetadataLocation{DimensionRange: &sheets.DimensionRange{
+				SheetId: sheetid, Dimension: "ROWS", StartIndex: int64(row), EndIndex: int64(row) + 1}},
+		}}}, hideRowsRequest(sheetid, int64(row), true))
+	}
+	if err = is.UpdateRangesWith(rowsArray, ranges, WriteOptions{ValueInputOption: UserEntered}, spreadsheetId); err != nil {
+		return err
+	}
+	_, err = b.Do()
+	return err
+}
+
+// RestoreRows reverts SoftDeleteRows on the zero-based rows of sheetName.
+func (is *Gsheet) RestoreRows(sheetName string, indices []int, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, err := is.GetSheetIdFromNAme(sheetName, spreadsheetId)
+	if err != nil {
+		return err
+	}
+	col, err := is.softDeleteCol(spreadsheetId, sheetName, false)
+	if err != nil {
+		return err
+	}
+	ranges := []string{}
+	b := is.Batch(spreadsheetId)
+	for _, row := range indices {
+		if err = is.checkGrid(spreadsheetId, sheetid, RowsRange("", row, row)); err != nil {
+			return err
+		}
+		if col >= 0 {
+			ranges = append(ranges, CellRange(sheetName, col, row).String())
+		}
+		b.Raw(&sheets.Request{DeleteDeveloperMetadata: &sheets.DeleteDeveloperMetadataRequest{DataFilter: &sheets.DataFilter{
+			DeveloperMetadataLookup: &sheets.DeveloperMetadataLookup{
+				MetadataKey:              softDeleteKey,
+				MetadataLocation:         &sheets.DeveloperMetadataLocation{DimensionRange: &sheets.DimensionRange{SheetId: sheetid, Dimension: "ROWS", StartIndex: int64(row), EndIndex: int64(row) + 1}},
+				LocationMatchingStrategy: "EXACT_LOCATION",
+			}}}}, hideRowsRequest(sheetid, int64(row), false))
+	}
+	if len(ranges) != 0 {
+		if err = is.ClearRanges(sheetid, ranges, spreadsheetId); err != nil {
+			return err
+		}
+	}
+	_, err = b.Do()
+	return err
+}
+
+// PurgeSoftDeleted permanently deletes the rows of sheetName soft deleted
+// more than olderThan ago and returns how many were removed.
+func (is *Gsheet) PurgeSoftDeleted(sheetName string, olderThan time.Duration, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	col, err := is.softDeleteCol(spreadsheetId, sheetName, false)
+	if err != nil || col < 0 {
+		return 0, err
+	}
+	sheetid, err := is.GetSheetIdFromNAme(sheetName, spreadsheetId)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := is.getValues(spreadsheetId, ColumnsRange(sheetName, col, col).String(), ReadOptions{})
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+	rows := []int{}
+	for r, v := range stringRows(resp.Values) {
+		if r == 0 || len(v) == 0 {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(v[0])); err == nil && t.Before(cutoff) {
+			rows = append(rows, r)
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if err = is.deleteRows(spreadsheetId, sheetid, rows); err != nil {
+		return 0, fmt.Errorf("purge %s: %w", sheetName, err)
+	}
+	return len(rows), nil
+}
@@ -0,0 +1,120 @@
+package gogsheet
+
+import (
+	"fmt"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// CreateSpreadsheet creates a spreadsheet titled title with a tab per name
+// of sheetNames, in order, or the default single tab when empty, and returns
+// its id. With bind the client uses it as its default spreadsheet from then
+// on. The spreadsheet is owned by the authorized account; share it through
+// Drive to open it elsewhere.
+func (is *Gsheet) CreateSpreadsheet(title string, sheetNames []string, bind bool) (string, error) {
+	// the id is only known after the call, so it can not be recorded
+	if err := is.readable(); err != nil {
+		return "", err
+	}
+	rq := &sheets.Spreadsheet{Properties: &sheets.SpreadsheetProperties{Title: title}}
+	seen := map[string]bool{}
+	for i, name := range sheetNames {
+		if seen[name] {
+			return "", fmt.Errorf("sheet %s listed twice", name)
+		}
+		seen[name] = true
+		rq.Sheets = append(rq.Sheets, &sheets.Sheet{Properties: &sheets.SheetProperties{Title: name, Index: int64(i)}})
+	}
+	var resp *sheets.Spreadsheet
+	// a retried create would leave a second spreadsheet behind
+	err := is.retry(false, func() (err error) {
+		resp, err = is.Spreadsheets.Create(rq).Fields("spreadsheetId").Do()
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if bind {
+		is.UpdateSpreadsheetId(resp.SpreadsheetId)
+	}
+	return resp.SpreadsheetId, nil
+}
+
+// CloneSpreadsheet copies the spreadsheet templateId through Drive, with all
+// tabs, formats and scripts, as newTitle into the folder destFolderId, or
+// next to the template when empty. It returns a client sharing the settings
+// of is with the copy as default spreadsheet. Needs the drive.DriveScope.
+func (is *Gsheet) CloneSpreadsheet(templateId, newTitle, destFolderId string) (*Gsheet, error) {
+	if err := is.readable(); err != nil {
+		return nil, err
+	}
+	srv, err := is.driveService()
+	if err != nil {
+		return nil, err
+	}
+	file := &drive.File{Name: newTitle, MimeType: spreadsheetMimeType}
+	if len(destFolderId) != 0 {
+		file.Parents = []string{destFolderId}
+	}
+	var f *drive.File
+	// a retried copy would leave a second spreadsheet behind
+	err = is.retry(false, func() (err error) {
+		f, err = srv.Files.Copy(templateId, file).Fields("id").SupportsAllDrives(true).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := is.clone()
+	c.spreadsheetId = f.Id
+	return c, nil
+}
+
+// UpdateSpreadsheetProperties updates the fields of props, a mask such as
+// "title", "defaultFormat.textFormat.fontFamily" or "autoRecalc", of the
+// spreadsheet.
+func (is *Gsheet) UpdateSpreadsheetProperties(props *sheets.SpreadsheetProperties, fields string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("empty fields mask")
+	}
+	_, err := is.Batch(spreadsheetId).UpdateSpreadsheetProperties(props, fields).Do()
+	if err != nil {
+		return err
+	}
+	// locale and time zone are cached for typed reads
+	is.mutex.Lock()
+	delete(is.locales, spreadsheetId)
+	is.mutex.Unlock()
+	return nil
+}
+
+// SetSpreadsheetTitle renames the spreadsheet.
+func (is *Gsheet) SetSpreadsheetTitle(title string, sprids ...string) error {
+	if len(title) == 0 {
+		return fmt.Errorf("empty spreadsheet title")
+	}
+	return is.UpdateSpreadsheetProperties(&sheets.SpreadsheetProperties{Title: title}, "title", sprids...)
+}
+
+// SetLocale sets the locale of the spreadsheet, e.g. "en_US" or "vi_VN",
+// which decides how dates and numbers are parsed and shown.
+func (is *Gsheet) SetLocale(locale string, sprids ...string) error {
+	if len(locale) == 0 {
+		return fmt.Errorf("empty locale")
+	}
+	return is.UpdateSpreadsheetProperties(&sheets.SpreadsheetProperties{Locale: locale}, "locale", sprids...)
+}
+
+// SetTimeZone sets the time zone of the spreadsheet, an IANA name such as
+// "Asia/Ho_Chi_Minh", used by NOW() and date parsing.
+func (is *Gsheet) SetTimeZone(tz string, sprids ...string) error {
+	if len(tz) == 0 {
+		return fmt.Errorf("empty time zone")
+	}
+	return is.UpdateSpreadsheetProperties(&sheets.SpreadsheetProperties{TimeZone: tz}, "timeZone", sprids...)
+}
@@ -0,0 +1,125 @@
+package gogsheet
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// AdaptiveOptions bounds adaptive chunking. Zero fields take the defaults.
+type AdaptiveOptions struct {
+	TargetLatency time.Duration // wanted duration of one chunk call, default 2s
+	MinRows       int           // default 200
+	MaxRows       int           // default 50000
+	MaxParallel   int           // upper bound of concurrent chunk calls, default 4
+}
+
+// ChunkStat is what the tuner learned about one sheet.
+type ChunkStat struct {
+	Rows       int     // current chunk size in rows
+	Parallel   int     // current number of concurrent chunk calls
+	RowsPerSec float64 // smoothed throughput of one call
+	Calls      int
+	Throttled  int // calls rejected by quota (HTTP 429)
+}
+
+type chunkTuner struct {
+	mutex sync.Mutex
+	opts  AdaptiveOptions
+	stats map[string]*ChunkStat
+}
+
+// SetAdaptiveChunking lets chunked reads called without an explicit chunk
+// size measure each call and adjust chunk size and parallelism per sheet:
+// chunks grow or shrink toward opts.TargetLatency, parallelism grows by one
+// while calls are fast and both are halved when quota is exceeded. Updates
+// without WriteOptions.ChunkRows are tuned the same way per spreadsheet,
+// chunk size only as chunks are written in order. Nil disables it and
+// chunked reads and writes fall back to DefaultChunkRows and
+// DefaultWriteChunkRows.
+func (is *Gsheet) SetAdaptiveChunking(opts *AdaptiveOptions) {
+	if opts == nil {
+		is.tuner = nil
+		return
+	}
+	o := *opts
+	if o.TargetLatency <= 0 {
+		o.TargetLatency = 2 * time.Second
+	}
+	if o.MinRows <= 0 {
+		o.MinRows = 200
+	}
+	if o.MaxRows < o.MinRows {
+		o.MaxRows = max(50000, o.MinRows)
+	}
+	if o.MaxParallel <= 0 {
+		o.MaxParallel = 4
+	}
+	is.tuner = &chunkTuner{opts: o, stats: map[string]*ChunkStat{}}
+}
+
+// ChunkStats returns the tuner's view per "spreadsheetId!sheet" for reads
+// and "write:spreadsheetId" for updates, nil when adaptive chunking is off.
+func (is *Gsheet) ChunkStats() map[string]ChunkStat {
+	t := is.tuner
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	ret := map[string]ChunkStat{}
+	for k, s := range t.stats {
+		ret[k] = *s
+	}
+	return ret
+}
+
+func (t *chunkTuner) stat(key string) *ChunkStat {
+	s, ok := t.stats[key]
+	if !ok {
+		s = &ChunkStat{Rows: min(max(DefaultChunkRows, t.opts.MinRows), t.opts.MaxRows), Parallel: 1}
+		t.stats[key] = s
+	}
+	return s
+}
+
+// plan returns the chunk size and parallelism to use next on key.
+func (t *chunkTuner) plan(key string) (rows, parallel int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	s := t.stat(key)
+	return s.Rows, s.Parallel
+}
+
+// observe records a chunk call of rows rows that took d.
+func (t *chunkTuner) observe(key string, rows int, d time.Duration, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	s := t.stat(key)
+	s.Calls++
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusTooManyRequests {
+		s.Throttled++
+		s.Rows = max(s.Rows/2, t.opts.MinRows)
+		s.Parallel = max(s.Parallel/2, 1)
+		return
+	}
+	if err != nil || rows <= 0 || d <= 0 {
+		return
+	}
+	rate := float64(rows) / d.Seconds()
+	if s.RowsPerSec == 0 {
+		s.RowsPerSec = rate
+	} else {
+		s.RowsPerSec = 0.7*s.RowsPerSec + 0.3*rate
+	}
+	// move halfway toward the size that would take TargetLatency
+	target := int(s.RowsPerSec * t.opts.TargetLatency.Seconds())
+	s.Rows = min(max((s.Rows+target)/2, t.opts.MinRows), t.opts.MaxRows)
+	if d < t.opts.TargetLatency/2 && s.Parallel < t.opts.MaxParallel {
+		s.Parallel++
+	}
+}
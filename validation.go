@@ -0,0 +1,75 @@
+package gogsheet
+
+import (
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// setValidation applies rule to every cell of rangeA1, nil removing it.
+func (is *Gsheet) setValidation(spreadsheetId, rangeA1 string, rule *DataValidation) error {
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	_, err = is.Batch(spreadsheetId).SetDataValidation(sheetid, r, rule).Do()
+	return err
+}
+
+// SetCheckbox turns every cell of rangeA1 into a checkbox. Empty cells show
+// unchecked; existing values other than TRUE and FALSE are flagged invalid.
+func (is *Gsheet) SetCheckbox(rangeA1 string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.setValidation(spreadsheetId, rangeA1, &DataValidation{Type: "BOOLEAN"})
+}
+
+// SetCheckboxValue checks or unchecks every checkbox of rangeA1.
+func (is *Gsheet) SetCheckboxValue(rangeA1 string, checked bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	sheetid, r, err := is.gridOf(spreadsheetId, rangeA1)
+	if err != nil {
+		return err
+	}
+	value := &sheets.ExtendedValue{BoolValue: &checked}
+	_, err = is.Batch(spreadsheetId).RepeatCell(sheetid, r, &sheets.CellData{UserEnteredValue: value}, "userEnteredValue").Do()
+	return err
+}
+
+// SetDropdown restricts every cell of rangeA1 to options, shown as a
+// dropdown. With strict other input is rejected, otherwise only flagged.
+func (is *Gsheet) SetDropdown(rangeA1 string, options []string, strict bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.setValidation(spreadsheetId, rangeA1, &DataValidation{Type: "ONE_OF_LIST", Values: options, Strict: strict, ShowDropdown: true})
+}
+
+// SetDropdownFromRange is SetDropdown with the options read live from the
+// cells of sourceRange, e.g. "Lists!A2:A".
+func (is *Gsheet) SetDropdownFromRange(rangeA1, sourceRange string, strict bool, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	if !strings.HasPrefix(sourceRange, "=") {
+		sourceRange = "=" + sourceRange
+	}
+	return is.setValidation(spreadsheetId, rangeA1, &DataValidation{Type: "ONE_OF_RANGE", Values: []string{sourceRange}, Strict: strict, ShowDropdown: true})
+}
+
+// ClearDataValidation removes the validation rules of rangeA1, dropdowns and
+// checkboxes included; values are kept.
+func (is *Gsheet) ClearDataValidation(rangeA1 string, sprids ...string) error {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.setValidation(spreadsheetId, rangeA1, nil)
+}
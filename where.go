@@ -0,0 +1,189 @@
+package gogsheet
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// readTable reads sheetName bypassing the cache, first row as header.
+func (is *Gsheet) readTable(spreadsheetId, sheetName string) ([][]string, error) {
+	resp, err := is.getValues(spreadsheetId, NewRange(sheetName).String(), is.readOptions)
+	if err != nil {
+		return nil, err
+	}
+	return stringRows(resp.Values), nil
+}
+
+// UpdateRowsWhere calls mutate on every row of sheetName, first row as
+// header, for which predicate returns true, and writes back only the cells
+// mutate changed, in one batch. Keys of the maps are header names; setting a
+// key that is not a header is an error and nothing is written. It returns the
+// number of rows changed.
+func (is *Gsheet) UpdateRowsWhere(sheetName string, predicate func(row map[string]string) bool, mutate func(row map[string]interface{}), sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	rows, err := is.readTable(spreadsheetId, sheetName)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	header := rows[0]
+	records := rowsToRecords(rows)
+	rowsArray, ranges := [][][]interface{}{}, []string{}
+	changed := 0
+	for i, rec := range records {
+		if !predicate(rec) {
+			continue
+		}
+		row := make(map[string]interface{}, len(rec))
+		for k, v := range rec {
+			row[k] = v
+		}
+		mutate(row)
+		for k := range row {
+			if _, ok := rec[k]; !ok {
+				return 0, fmt.Errorf("column %s not in header of %s", k, sheetName)
+			}
+		}
+		dirty := false
+		for c, h := range header {
+			v := row[h]
+			if v == nil {
+				v = "" // a deleted key clears the cell
+			}
+			if fmt.Sprint(v) != rec[h] {
+				rowsArray = append(rowsArray, [][]interface{}{{v}})
+				ranges = append(ranges, CellRange(sheetName, c, i+1).String())
+				dirty = true
+			}
+		}
+		if dirty {
+			changed++
+		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+	if err = is.UpdateRanges(rowsArray, ranges, spreadsheetId); err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// DeleteRowsWhere deletes every row of sheetName, first row as header, for
+// which predicate returns true, in one batch, and returns how many were
+// removed.
+func (is *Gsheet) DeleteRowsWhere(sheetName string, predicate func(row map[string]string) bool, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	rows, err := is.readTable(spreadsheetId, sheetName)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	matched := []int{}
+	for i, rec := range rowsToRecords(rows) {
+		if predicate(rec) {
+			matched = append(matched, i+1)
+		}
+	}
+	if len(matched) == 0 {
+		return 0, nil
+	}
+	sheetid, err := is.GetSheetIdFromNAme(sheetName, spreadsheetId)
+	if err != nil {
+		return 0, err
+	}
+	if err = is.deleteRows(spreadsheetId, sheetid, matched); err != nil {
+		return 0, err
+	}
+	return len(matched), nil
+}
+
+// deleteRows deletes the zero-based rows of sheetid in one batch. Adjacent
+// rows are merged and ranges go bottom up, so earlier deletions do not shift
+// the later ones.
+func (is *Gsheet) deleteRows(spreadsheetId string, sheetid int64, rows []int) error {
+	rows = append([]int(nil), rows...)
+	sort.Sort(sort.Reverse(sort.IntSlice(rows)))
+	b := is.Batch(spreadsheetId)
+	for i := 0; i < len(rows); {
+		end := rows[i]
+		start := end
+		for i++; i < len(rows) && rows[i] >= start-1; i++ {
+			start = min(start, rows[i])
+		}
+		if err := is.checkGrid(spreadsheetId, sheetid, RowsRange("", start, end)); err != nil {
+			return err
+		}
+		b.Raw(&sheets.Request{DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: &sheets.DimensionRange{SheetId: sheetid, Dimension: "ROWS", StartIndex: int64(start), EndIndex: int64(end) + 1}}})
+	}
+	_, err := b.Do()
+	return err
+}
+
+// WriteDiff writes newValues at rangeA1 like UpdateRange, but first reads
+// the range and sends only the runs of cells that differ, as small ranges of
+// one batch, so the edit history shows what really changed. Cells are
+// compared as formulas or unformatted values; nil values are skipped. It
+// returns the number of cells written.
+func (is *Gsheet) WriteDiff(rangeA1 string, newValues [][]interface{}, sprids ...string) (int, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	r, err := ParseRange(rangeA1)
+	if err != nil {
+		return 0, err
+	}
+	if bareName(rangeA1) {
+		// a named range is diffed at the cells it points to
+		if _, r, err = is.gridOf(spreadsheetId, rangeA1); err != nil {
+			return 0, err
+		}
+	}
+	resp, err := is.getValues(spreadsheetId, r.String(), ReadOptions{ValueRenderOption: Formula})
+	if err != nil {
+		return 0, err
+	}
+	current := stringRows(resp.Values)
+	startCol, startRow := max(r.StartCol, 0), max(r.StartRow, 0)
+	rowsArray, ranges := [][][]interface{}{}, []string{}
+	written := 0
+	for i, row := range newValues {
+		var run []interface{}
+		flush := func(end int) {
+			if len(run) != 0 {
+				cells := &Range{Sheet: r.Sheet, StartCol: startCol + end - len(run), StartRow: startRow + i, EndCol: startCol + end - 1, EndRow: startRow + i}
+				rowsArray = append(rowsArray, [][]interface{}{run})
+				ranges = append(ranges, cells.String())
+				written += len(run)
+				run = nil
+			}
+		}
+		for j, v := range row {
+			old := ""
+			if i < len(current) && j < len(current[i]) {
+				old = current[i][j]
+			}
+			if v == nil || fmt.Sprint(v) == old {
+				flush(j)
+				continue
+			}
+			run = append(run, v)
+		}
+		flush(len(row))
+	}
+	if written == 0 {
+		return 0, nil
+	}
+	if err = is.UpdateRanges(rowsArray, ranges, spreadsheetId); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
@@ -0,0 +1,235 @@
+package gogsheet
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// WorkbookSpec declares the structure a spreadsheet must have. ApplyWorkbook
+// only adds or updates what differs; tabs, protections and named ranges the
+// spec does not mention are left alone.
+type WorkbookSpec struct {
+	Tabs        []TabSpec
+	NamedRanges map[string]string // name to A1 range with sheet name
+}
+
+// TabSpec declares one tab. Ranges are in A1 notation without sheet name.
+type TabSpec struct {
+	Title           string
+	Headers         []string                   // first row, extra live columns are kept
+	Validations     map[string]*DataValidation // range to rule, e.g. "C2:C"; nil for no rule
+	Protected       bool                       // protect the whole tab
+	ProtectedRanges []string
+}
+
+const workbookFields = "sheets(properties(sheetId,title,gridProperties(rowCount,columnCount)),protectedRanges(range))," +
+	"namedRanges(namedRangeId,name,range)"
+
+// DiffWorkbook lists the changes ApplyWorkbook would make, without making them.
+func (is *Gsheet) DiffWorkbook(spec *WorkbookSpec, sprids ...string) ([]string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.applyWorkbook(spreadsheetId, spec, false)
+}
+
+// ApplyWorkbook migrates the spreadsheet to spec: missing tabs are created,
+// then headers, validations, protections and named ranges that differ are
+// fixed in one batchUpdate and one values write. It returns the changes made,
+// none when the spreadsheet already matches.
+func (is *Gsheet) ApplyWorkbook(spec *WorkbookSpec, sprids ...string) ([]string, error) {
+	spreadsheetId := is.spreadsheetId
+	if len(sprids) != 0 {
+		spreadsheetId = sprids[0]
+	}
+	return is.applyWorkbook(spreadsheetId, spec, true)
+}
+
+func (is *Gsheet) applyWorkbook(spreadsheetId string, spec *WorkbookSpec, apply bool) ([]string, error) {
+	resp, err := is.GetMetadataOf(spreadsheetId, workbookFields)
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]*sheets.Sheet{}
+	for _, sh := range resp.Sheets {
+		live[sh.Properties.Title] = sh
+	}
+	changes := []string{}
+	ids := map[string]int64{}
+	missing := []string{}
+	for _, tab := range spec.Tabs {
+		if sh, ok := live[tab.Title]; ok {
+			ids[tab.Title] = sh.Properties.SheetId
+		} else {
+			missing = append(missing, tab.Title)
+			changes = append(changes, fmt.Sprintf("add tab %s", tab.Title))
+		}
+	}
+	if apply && len(missing) != 0 {
+		b := is.Batch(spreadsheetId)
+		for _, title := range missing {
+			b.AddSheet(title)
+		}
+		res, err := b.Apply()
+		if err != nil {
+			return nil, err
+		}
+		for _, reply := range res.Replies {
+			if reply.AddSheet != nil {
+				ids[reply.AddSheet.Properties.Title] = reply.AddSheet.Properties.SheetId
+			}
+		}
+	}
+	existing := []string{}
+	for _, tab := range spec.Tabs {
+		if _, ok := live[tab.Title]; ok {
+			existing = append(existing, tab.Title)
+		}
+	}
+	headers, err := is.readHeaders(spreadsheetId, existing)
+	if err != nil {
+		return nil, err
+	}
+	b := is.Batch(spreadsheetId)
+	rowsArray, ranges := [][][]interface{}{}, []string{}
+	for _, tab := range spec.Tabs {
+		sh := live[tab.Title] // nil for a new tab
+		sheetid := ids[tab.Title]
+		if len(tab.Headers) != 0 {
+			cur := headers[tab.Title]
+			if len(cur) < len(tab.Headers) || !slices.Equal(cur[:len(tab.Headers)], tab.Headers) {
+				row := make([]interface{}, len(tab.Headers))
+				for i, h := range tab.Headers {
+					row[i] = h
+				}
+				rowsArray = append(rowsArray, [][]interface{}{row})
+				ranges = append(ranges, CellRange(tab.Title, 0, 0).String())
+				changes = append(changes, fmt.Sprintf("set headers of %s", tab.Title))
+			}
+		}
+		keys := make([]string, 0, len(tab.Validations))
+		for k := range tab.Validations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			r, err := ParseRange(k)
+			if err != nil {
+				return nil, err
+			}
+			r.Sheet = tab.Title
+			same := false
+			if sh != nil {
+				if same, err = is.validationMatches(spreadsheetId, sh, r, tab.Validations[k]); err != nil {
+					return nil, err
+				}
+			}
+			if !same {
+				b.SetDataValidation(sheetid, r, tab.Validations[k])
+				changes = append(changes, fmt.Sprintf("set validation of %s", r))
+			}
+		}
+		protect := append([]string(nil), tab.ProtectedRanges...)
+		if tab.Protected {
+			protect = append(protect, "")
+		}
+		for _, a1 := range protect {
+			r := NewRange("")
+			if len(a1) != 0 {
+				if r, err = ParseRange(a1); err != nil {
+					return nil, err
+				}
+			}
+			gr := rangeToGrid(sheetid, r)
+			if sh != nil && slices.ContainsFunc(sh.ProtectedRanges, func(pr *sheets.ProtectedRange) bool { return sameGrid(pr.Range, gr) }) {
+				continue
+			}
+			b.Raw(&sheets.Request{AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: &sheets.ProtectedRange{Range: gr}}})
+			r.Sheet = tab.Title
+			changes = append(changes, fmt.Sprintf("protect %s", r))
+		}
+	}
+	names := make([]string, 0, len(spec.NamedRanges))
+	for name := range spec.NamedRanges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r, err := ParseRange(spec.NamedRanges[name])
+		if err != nil {
+			return nil, err
+		}
+		sheetid, ok := ids[r.Sheet]
+		if !ok {
+			if sh, found := live[r.Sheet]; found {
+				sheetid = sh.Properties.SheetId
+			} else if apply {
+				return nil, fmt.Errorf("named range %s: can not find sheet %s", name, r.Sheet)
+			}
+		}
+		gr := rangeToGrid(sheetid, r)
+		idx := slices.IndexFunc(resp.NamedRanges, func(nr *sheets.NamedRange) bool { return nr.Name == name })
+		switch {
+		case idx < 0:
+			b.AddNamedRange(name, sheetid, r)
+			changes = append(changes, fmt.Sprintf("add named range %s", name))
+		case !sameGrid(resp.NamedRanges[idx].Range, gr):
+			b.Raw(&sheets.Request{UpdateNamedRange: &sheets.UpdateNamedRangeRequest{
+				NamedRange: &sheets.NamedRange{NamedRangeId: resp.NamedRanges[idx].NamedRangeId, Name: name, Range: gr},
+				Fields:     "range",
+			}})
+			changes = append(changes, fmt.Sprintf("move named range %s", name))
+		}
+	}
+	if !apply {
+		return changes, nil
+	}
+	if len(ranges) != 0 {
+		if err = is.UpdateRangesWith(rowsArray, ranges, WriteOptions{ValueInputOption: Raw}, spreadsheetId); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = b.Do(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// validationMatches reports whether every cell of r on sh carries want.
+func (is *Gsheet) validationMatches(spreadsheetId string, sh *sheets.Sheet, r *Range, want *DataValidation) (bool, error) {
+	grid, err := is.getGrid(spreadsheetId, r.String(), "dataValidation")
+	if err != nil {
+		return false, err
+	}
+	same := true
+	rows, cols := gridCells(grid, func(_, _ int, cell *sheets.CellData) {
+		same = same && want.Equal(dataValidationOf(cell.DataValidation))
+	})
+	if want == nil {
+		return same, nil
+	}
+	// cells without rule at the end of the range are not returned
+	wantRows, wantCols := r.EndRow, r.EndCol
+	if gp := sh.Properties.GridProperties; gp != nil {
+		if wantRows < 0 {
+			wantRows = int(gp.RowCount) - 1
+		}
+		if wantCols < 0 {
+			wantCols = int(gp.ColumnCount) - 1
+		}
+	}
+	return same && rows >= wantRows-max(r.StartRow, 0)+1 && cols >= wantCols-max(r.StartCol, 0)+1, nil
+}
+
+// sameGrid compares the bounds of two grid ranges of the same sheet.
+func sameGrid(a, b *sheets.GridRange) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.SheetId == b.SheetId && a.StartRowIndex == b.StartRowIndex && a.EndRowIndex == b.EndRowIndex &&
+		a.StartColumnIndex == b.StartColumnIndex && a.EndColumnIndex == b.EndColumnIndex
+}
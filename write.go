@@ -0,0 +1,173 @@
+package gogsheet
+
+// Value input options for writes.
+const (
+	// UserEntered parses values as if typed into the UI: formulas are
+	// evaluated and numbers and dates are converted.
+	UserEntered = "USER_ENTERED"
+	// Raw stores values as given, so "=SUM(A1:A2)" or "2024-01-02" stay text.
+	Raw = "RAW"
+)
+
+// Insert data options for appends.
+const (
+	// Overwrite writes appended rows over the cells below the table,
+	// e.g. a totals row.
+	Overwrite = "OVERWRITE"
+	// InsertRows inserts new rows for the appended data, shifting down what
+	// is below the table.
+	InsertRows = "INSERT_ROWS"
+)
+
+// WriteOptions controls how written values are interpreted. Empty fields
+// keep the defaults (USER_ENTERED, OVERWRITE, ROWS).
+type WriteOptions struct {
+	ValueInputOption string
+	InsertDataOption string // appends only
+	MajorDimension   string // DimensionColumns when each inner slice is a column
+	// ChunkRows splits updates of more rows into several calls so they stay
+	// under the API request size limit; 0 uses the adaptive chunk size when
+	// SetAdaptiveChunking is on, DefaultWriteChunkRows otherwise, and a
+	// negative value sends everything at once.
+	ChunkRows int
+}
+
+// DefaultWriteChunkRows is the number of rows sent per update call when
+// WriteOptions.ChunkRows is 0 and adaptive chunking is off.
+var DefaultWriteChunkRows = 10000
+
+func (opts WriteOptions) valueInputOption() string {
+	if len(opts.ValueInputOption) == 0 {
+		return UserEntered
+	}
+	return opts.ValueInputOption
+}
+
+func (opts WriteOptions) insertDataOption() string {
+	if len(opts.InsertDataOption) == 0 {
+		return Overwrite
+	}
+	return opts.InsertDataOption
+}
+
+func (opts WriteOptions) majorDimension() string {
+	if len(opts.MajorDimension) == 0 {
+		return DimensionRows
+	}
+	return opts.MajorDimension
+}
+
+// writeChunk returns the number of rows per update call on spreadsheetId and
+// the tuner key to report the calls to, "" when they are not tuned.
+func (is *Gsheet) writeChunk(spreadsheetId string, opts WriteOptions) (int, string) {
+	if opts.ChunkRows != 0 {
+		return opts.ChunkRows, ""
+	}
+	if tuner := is.tuner; tuner != nil {
+		key := "write:" + spreadsheetId
+		rows, _ := tuner.plan(key)
+		return rows, key
+	}
+	return DefaultWriteChunkRows, ""
+}
+
+// resolveSplit returns rangeData with the bare names of ranges holding more
+// than limit rows resolved to cells, a sheet to its A1 cell, so chunkUpdates
+// can split them. Names that can not be resolved are kept.
+func (is *Gsheet) resolveSplit(spreadsheetId string, rowsArray [][][]interface{}, rangeData []string, opts WriteOptions, limit int) []string {
+	if limit <= 0 || opts.majorDimension() == DimensionColumns {
+		return rangeData
+	}
+	ret := append([]string{}, rangeData...)
+	for i, a1 := range rangeData {
+		if len(rowsArray[i]) <= limit || !bareName(a1) {
+			continue
+		}
+		_, r, err := is.gridOf(spreadsheetId, a1)
+		if err != nil {
+			continue
+		}
+		ret[i] = r.String()
+		if r.StartCol < 0 && r.StartRow < 0 {
+			ret[i] = CellRange(r.Sheet, 0, 0).String()
+		}
+	}
+	return ret
+}
+
+type updateChunk struct {
+	rowsArray [][][]interface{}
+	ranges    []string
+	rows      int
+}
+
+// chunkUpdates splits the ranges holding more than limit rows into
+// consecutive parts, each written from its top-left cell, and groups them,
+// in order, into chunks of at most that many rows. Column-major data, ranges
+// that can not be parsed and bare names, see resolveSplit, are never split.
+func chunkUpdates(rowsArray [][][]interface{}, rangeData []string, opts WriteOptions, limit int) []updateChunk {
+	if limit <= 0 {
+		return []updateChunk{{rowsArray: rowsArray, ranges: rangeData}}
+	}
+	columns := opts.majorDimension() == DimensionColumns
+	chunks := []updateChunk{}
+	cur, size := updateChunk{}, 0
+	add := func(rows [][]interface{}, a1 string, n int) {
+		if size != 0 && size+n > limit {
+			chunks = append(chunks, cur)
+			cur, size = updateChunk{}, 0
+		}
+		cur.rowsArray = append(cur.rowsArray, rows)
+		cur.ranges = append(cur.ranges, a1)
+		size += n
+		cur.rows = size
+	}
+	for i, rows := range rowsArray {
+		if columns {
+			n := 0
+			for _, col := range rows {
+				n = max(n, len(col))
+			}
+			add(rows, rangeData[i], n)
+			continue
+		}
+		r, err := ParseRange(rangeData[i])
+		if err != nil || len(rows) <= limit || bareName(rangeData[i]) {
+			add(rows, rangeData[i], len(rows))
+			continue
+		}
+		for start := 0; start < len(rows); start += limit {
+			part := rows[start:min(start+limit, len(rows))]
+			// an anchor fits rows of any width, where the bounds of r may
+			// be a single cell
+			anchor := CellRange(r.Sheet, max(r.StartCol, 0), max(r.StartRow, 0)+start)
+			add(part, anchor.String(), len(part))
+		}
+	}
+	if len(cur.ranges) != 0 {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
+
+// UpdateColumns writes cols to rangeData column by column, so a computed
+// column is written as one slice instead of one-element rows.
+func (is *Gsheet) UpdateColumns(cols [][]interface{}, rangeData string, sprids ...string) error {
+	opts := is.writeOptions
+	opts.MajorDimension = DimensionColumns
+	return is.UpdateRangeWith(cols, rangeData, opts, sprids...)
+}
+
+// AppendColumns appends cols below the table found in rangeData, each slice
+// going down one column.
+func (is *Gsheet) AppendColumns(cols [][]interface{}, rangeData string, sprids ...string) (*AppendResult, error) {
+	opts := is.writeOptions
+	opts.MajorDimension = DimensionColumns
+	return is.AppendRowsWith(cols, rangeData, opts, sprids...)
+}
+
+// SetWriteOptions sets the options used by UpdateRange, UpdateRanges,
+// AppendRows and the other writes without a With variant.
+func (is *Gsheet) SetWriteOptions(opts WriteOptions) {
+	is.writeOptions = opts
+}
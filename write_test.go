@@ -0,0 +1,62 @@
+package gogsheet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChunkUpdates(t *testing.T) {
+	rows := make([][]interface{}, 25000)
+	for i := range rows {
+		rows[i] = []interface{}{i, "b", "c"}
+	}
+	tests := []struct {
+		rangeA1 string
+		want    []string
+	}{
+		{"Data!A1", []string{"Data!A1", "Data!A10001", "Data!A20001"}},
+		{"Data!B2:D25001", []string{"Data!B2", "Data!B10002", "Data!B20002"}},
+		{"'My Sheet'!A:C", []string{"'My Sheet'!A1", "'My Sheet'!A10001", "'My Sheet'!A20001"}},
+		{"MyNamedRange", []string{"MyNamedRange"}},
+	}
+	for _, tt := range tests {
+		chunks := chunkUpdates([][][]interface{}{rows}, []string{tt.rangeA1}, WriteOptions{}, 10000)
+		got, n := []string{}, 0
+		for _, c := range chunks {
+			got = append(got, c.ranges...)
+			for _, part := range c.rowsArray {
+				n += len(part)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ranges %v, want %v", tt.rangeA1, got, tt.want)
+		}
+		if n != len(rows) {
+			t.Errorf("%s: %d rows written, want %d", tt.rangeA1, n, len(rows))
+		}
+	}
+}
+
+func TestWriteChunkTuned(t *testing.T) {
+	is := &Gsheet{}
+	if rows, key := is.writeChunk("id", WriteOptions{}); rows != DefaultWriteChunkRows || key != "" {
+		t.Errorf("untuned: %d %q", rows, key)
+	}
+	is.SetAdaptiveChunking(&AdaptiveOptions{MinRows: 300, MaxRows: 300})
+	if rows, key := is.writeChunk("id", WriteOptions{}); rows != 300 || key != "write:id" {
+		t.Errorf("tuned: %d %q", rows, key)
+	}
+	if rows, key := is.writeChunk("id", WriteOptions{ChunkRows: 5}); rows != 5 || key != "" {
+		t.Errorf("explicit: %d %q", rows, key)
+	}
+}
+
+func TestResolveSplit(t *testing.T) {
+	is, _ := fakeGrid(t, 1000, 26, nil)
+	rows := make([][]interface{}, 20)
+	got := is.resolveSplit("id", [][][]interface{}{rows, rows, rows[:5]}, []string{"Data", "Missing", "Data"}, WriteOptions{}, 10)
+	want := []string{"Data!A1", "Missing", "Data"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}